@@ -25,15 +25,13 @@ import (
 	"time"
 
 	utils "github.com/coralogix/coralogix-operator/apis"
-	"github.com/go-logr/logr"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
-
-	"github.com/coralogix/coralogix-operator/controllers/clientset"
 	alerts "github.com/coralogix/coralogix-operator/controllers/clientset/grpc/alerts/v2"
 )
 
@@ -113,17 +111,47 @@ var (
 		NotifyOnTriggeredOnly:        alerts.NotifyOn_TRIGGERED_ONLY,
 		NotifyOnTriggeredAndResolved: alerts.NotifyOn_TRIGGERED_AND_RESOLVED,
 	}
-	msInHour       = int(time.Hour.Milliseconds())
-	msInMinute     = int(time.Minute.Milliseconds())
-	WebhooksClient clientset.OutboundWebhooksClientInterface
+	msInHour   = int(time.Hour.Milliseconds())
+	msInMinute = int(time.Minute.Milliseconds())
 )
 
+// WebhookResolver looks up the outbound webhooks of the Coralogix account of an alert, which its notifications refer to
+// by name and Coralogix by external ID.
+// +kubebuilder:object:generate=false
+type WebhookResolver interface {
+	// WebhookID returns the external ID of the outbound webhook named name, and false if there is none.
+	WebhookID(ctx context.Context, name string) (uint32, bool, error)
+	// WebhookName returns the name of the outbound webhook with the external ID id, and false if there is none.
+	WebhookName(ctx context.Context, id uint32) (string, bool, error)
+}
+
+// NotificationWebhooks resolves the outbound webhooks the notifications of an alert refer to into their external IDs,
+// and back.
+// +kubebuilder:object:generate=false
+type NotificationWebhooks struct {
+	// Resolver looks up the outbound webhooks notified with an integrationName. It may be nil when there are none.
+	Resolver WebhookResolver
+	// RefIDs are the external IDs of the OutboundWebhooks notified with an integrationRef, as returned by
+	// ResolveIntegrationRefs.
+	RefIDs map[OutboundWebhookReference]uint32
+}
+
 type ProtoTimeFrameAndRelativeTimeFrame struct {
 	TimeFrame         alerts.Timeframe
 	RelativeTimeFrame alerts.RelativeTimeframe
 }
 
+const (
+	// ManagedByLabelKey is the alert label set to ManagedByLabelValue on the alerts created by the operator.
+	ManagedByLabelKey   = "managed-by"
+	ManagedByLabelValue = "coralogix-operator"
+
+	DefaultRetriggeringPeriodMinutes int32    = 5
+	DefaultTimeZone                  TimeZone = "UTC+00"
+)
+
 // AlertSpec defines the desired state of Alert
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef)",message="accountRef can't be added or removed"
 type AlertSpec struct {
 	//+kubebuilder:validation:MinLength=0
 	Name string `json:"name"`
@@ -155,10 +183,25 @@ type AlertSpec struct {
 	Scheduling *Scheduling `json:"scheduling,omitempty"`
 
 	AlertType AlertType `json:"alertType"`
+
+	// The Coralogix account the resource is managed in. Defaults to the account the operator was started with.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+
+	// What to do when the remote alert is changed outside of the operator, e.g. in the Coralogix UI.
+	// Enforce overwrites the remote alert with the spec, and Report only records the difference in status.lastDrift.
+	// +optional
+	// +kubebuilder:default=Enforce
+	DriftPolicy *DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// What to do with the remote alert when the resource is deleted. Delete deletes it, and Retain leaves it in
+	// Coralogix, e.g. to move the resource to another namespace. Defaults to the operator's deletion policy.
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
-func (a *Alert) ExtractCreateAlertRequest(ctx context.Context, log logr.Logger) (*alerts.CreateAlertRequest, error) {
-	notificationGroups, err := expandNotificationGroups(ctx, log, a.Spec.NotificationGroups)
+func (a *Alert) ExtractCreateAlertRequest(ctx context.Context, webhooks NotificationWebhooks) (*alerts.CreateAlertRequest, error) {
+	notificationGroups, err := expandNotificationGroups(ctx, a.Spec.NotificationGroups, webhooks)
 	if err != nil {
 		return nil, err
 	}
@@ -994,38 +1037,101 @@ func expandShowInInsight(showInInsight *ShowInInsight) *alerts.ShowInInsight {
 	}
 }
 
-func expandNotificationGroups(ctx context.Context, log logr.Logger, notificationGroups []NotificationGroup) ([]*alerts.AlertNotificationGroups, error) {
-	webhooksNamesToIds, err := getWebhooksNamesToIds(ctx, log)
-	if err != nil {
-		return nil, err
-	}
+func expandNotificationGroups(ctx context.Context, notificationGroups []NotificationGroup, webhooks NotificationWebhooks) ([]*alerts.AlertNotificationGroups, error) {
 	result := make([]*alerts.AlertNotificationGroups, 0, len(notificationGroups))
 	for i, ng := range notificationGroups {
-		notificationGroup, err := expandNotificationGroup(ng, webhooksNamesToIds)
+		notificationGroup, err := expandNotificationGroup(ctx, ng, webhooks)
 		if err != nil {
-			return nil, fmt.Errorf("error on notificationGroups[%d] - %s", i, err.Error())
+			return nil, fmt.Errorf("error on notificationGroups[%d] - %w", i, err)
 		}
 		result = append(result, notificationGroup)
 	}
 	return result, nil
 }
 
-func getWebhooksNamesToIds(ctx context.Context, log logr.Logger) (map[string]uint32, error) {
-	webhooksNamesToIds := make(map[string]uint32)
-	log.V(1).Info("Listing all outgoing webhooks")
-	listWebhooksResp, err := WebhooksClient.List(ctx, &cxsdk.ListAllOutgoingWebhooksRequest{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list all outgoing webhooks %w", err)
+// UnresolvedWebhookError is returned when a notification refers to an outbound webhook that doesn't exist in Coralogix.
+// +kubebuilder:object:generate=false
+type UnresolvedWebhookError struct {
+	Name string
+	// ID is the external ID a remote alert refers to the outbound webhook with, when it has no Name.
+	ID uint32
+}
+
+func (e *UnresolvedWebhookError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("outbound webhook with the external id %d was not found in Coralogix", e.ID)
 	}
-	for _, webhook := range listWebhooksResp.GetDeployed() {
-		webhooksNamesToIds[webhook.GetName().GetValue()] = webhook.GetExternalId().GetValue()
+	return fmt.Sprintf("outbound webhook %q was not found in Coralogix", e.Name)
+}
+
+// OutboundWebhookNotReadyError is returned when a notification refers to an OutboundWebhook resource that doesn't exist
+// or wasn't created in Coralogix yet.
+// +kubebuilder:object:generate=false
+type OutboundWebhookNotReadyError struct {
+	Ref    OutboundWebhookReference
+	Reason string
+}
+
+func (e *OutboundWebhookNotReadyError) Error() string {
+	return fmt.Sprintf("outbound webhook %s is not ready: %s", e.Ref, e.Reason)
+}
+
+// IntegrationRefs returns the outbound webhooks the notifications of the alert refer to, as they are written in the spec.
+func (in *AlertSpec) IntegrationRefs() []OutboundWebhookReference {
+	var refs []OutboundWebhookReference
+	for _, notificationGroup := range in.NotificationGroups {
+		for _, notification := range notificationGroup.Notifications {
+			if notification.IntegrationRef != nil {
+				refs = append(refs, *notification.IntegrationRef)
+			}
+		}
 	}
-	return webhooksNamesToIds, nil
+	return refs
 }
 
-func expandNotificationGroup(notificationGroup NotificationGroup, webhooksNameToIds map[string]uint32) (*alerts.AlertNotificationGroups, error) {
+// ResolveIntegrationRefs returns the external IDs of the OutboundWebhooks the notifications of alert refer to, read with
+// reader. It fails with an OutboundWebhookNotReadyError when one of them doesn't exist or wasn't created in Coralogix yet.
+func ResolveIntegrationRefs(ctx context.Context, reader client.Reader, alert *Alert) (map[OutboundWebhookReference]uint32, error) {
+	refs := alert.Spec.IntegrationRefs()
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	ids := make(map[OutboundWebhookReference]uint32, len(refs))
+	for _, ref := range refs {
+		key := ref.ObjectKey(alert.Namespace)
+		notReady := &OutboundWebhookNotReadyError{Ref: OutboundWebhookReference{Name: key.Name, Namespace: key.Namespace}}
+
+		webhook := &OutboundWebhook{}
+		if err := reader.Get(ctx, key, webhook); err != nil {
+			if apierrors.IsNotFound(err) {
+				notReady.Reason = "it was not found"
+				return nil, notReady
+			}
+			return nil, fmt.Errorf("error on getting outbound webhook %s: %w", key, err)
+		}
+
+		switch {
+		case !webhook.DeletionTimestamp.IsZero():
+			notReady.Reason = "it is being deleted"
+			return nil, notReady
+		case ptr.Deref(webhook.Status.ExternalID, "") == "":
+			notReady.Reason = "it was not created in Coralogix yet"
+			return nil, notReady
+		}
+
+		id, err := strconv.ParseUint(*webhook.Status.ExternalID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid external ID %q of outbound webhook %s: %w", *webhook.Status.ExternalID, key, err)
+		}
+		ids[ref] = uint32(id)
+	}
+	return ids, nil
+}
+
+func expandNotificationGroup(ctx context.Context, notificationGroup NotificationGroup, webhooks NotificationWebhooks) (*alerts.AlertNotificationGroups, error) {
 	groupFields := utils.StringSliceToWrappedStringSlice(notificationGroup.GroupByFields)
-	notifications, err := expandNotifications(notificationGroup.Notifications, webhooksNameToIds)
+	notifications, err := expandNotifications(ctx, notificationGroup.Notifications, webhooks)
 	if err != nil {
 		return nil, err
 	}
@@ -1036,19 +1142,19 @@ func expandNotificationGroup(notificationGroup NotificationGroup, webhooksNameTo
 	}, nil
 }
 
-func expandNotifications(notifications []Notification, webhooksNameToIds map[string]uint32) ([]*alerts.AlertNotification, error) {
+func expandNotifications(ctx context.Context, notifications []Notification, webhooks NotificationWebhooks) ([]*alerts.AlertNotification, error) {
 	result := make([]*alerts.AlertNotification, 0, len(notifications))
 	for i, notification := range notifications {
-		expandedNotification, err := expandNotification(notification, webhooksNameToIds)
+		expandedNotification, err := expandNotification(ctx, notification, webhooks)
 		if err != nil {
-			return nil, fmt.Errorf("error on notifications[%d] - %s", i, err.Error())
+			return nil, fmt.Errorf("error on notifications[%d] - %w", i, err)
 		}
 		result = append(result, expandedNotification)
 	}
 	return result, nil
 }
 
-func expandNotification(notification Notification, webhooksNameToIds map[string]uint32) (*alerts.AlertNotification, error) {
+func expandNotification(ctx context.Context, notification Notification, webhooks NotificationWebhooks) (*alerts.AlertNotification, error) {
 	retriggeringPeriodSeconds := wrapperspb.UInt32(uint32(60 * notification.RetriggeringPeriodMinutes))
 	notifyOn := AlertSchemaNotifyOnToProtoNotifyOn[notification.NotifyOn]
 
@@ -1057,8 +1163,31 @@ func expandNotification(notification Notification, webhooksNameToIds map[string]
 		NotifyOn:                  &notifyOn,
 	}
 
+	if notification.IntegrationName != nil && notification.IntegrationRef != nil {
+		return nil, fmt.Errorf("required exactly on of 'integrationName' or 'integrationRef'")
+	}
+
 	if integrationName := notification.IntegrationName; integrationName != nil {
-		integrationID, _ := webhooksNameToIds[*integrationName]
+		if webhooks.Resolver == nil {
+			return nil, fmt.Errorf("outbound webhook %q can't be resolved without a Coralogix account", *integrationName)
+		}
+		integrationID, ok, err := webhooks.Resolver.WebhookID(ctx, *integrationName)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, &UnresolvedWebhookError{Name: *integrationName}
+		}
+		result.IntegrationType = &alerts.AlertNotification_IntegrationId{
+			IntegrationId: wrapperspb.UInt32(integrationID),
+		}
+	}
+
+	if integrationRef := notification.IntegrationRef; integrationRef != nil {
+		integrationID, ok := webhooks.RefIDs[*integrationRef]
+		if !ok {
+			return nil, &OutboundWebhookNotReadyError{Ref: *integrationRef, Reason: "it was not resolved"}
+		}
 		result.IntegrationType = &alerts.AlertNotification_IntegrationId{
 			IntegrationId: wrapperspb.UInt32(integrationID),
 		}
@@ -1067,7 +1196,7 @@ func expandNotification(notification Notification, webhooksNameToIds map[string]
 	emails := notification.EmailRecipients
 	{
 		if result.IntegrationType != nil && len(emails) != 0 {
-			return nil, fmt.Errorf("required exactly on of 'integrationName' or 'emailRecipients'")
+			return nil, fmt.Errorf("required exactly on of 'integrationName', 'integrationRef' or 'emailRecipients'")
 		}
 
 		if result.IntegrationType == nil {
@@ -1249,6 +1378,8 @@ func getNotificationsByIntegrationNameMap(notifications []Notification) map[stri
 	for _, notification := range notifications {
 		if notification.IntegrationName != nil {
 			notificationsByIntegrationName[*notification.IntegrationName] = notification
+		} else if notification.IntegrationRef != nil {
+			notificationsByIntegrationName["integrationRef:"+notification.IntegrationRef.String()] = notification
 		} else {
 			notificationsByIntegrationName["emailRecipients"] = notification
 		}
@@ -1256,7 +1387,7 @@ func getNotificationsByIntegrationNameMap(notifications []Notification) map[stri
 	return notificationsByIntegrationName
 }
 
-func (in *AlertSpec) ExtractUpdateAlertRequest(ctx context.Context, log logr.Logger, id string) (*alerts.UpdateAlertByUniqueIdRequest, error) {
+func (in *AlertSpec) ExtractUpdateAlertRequest(ctx context.Context, id string, webhooks NotificationWebhooks) (*alerts.UpdateAlertByUniqueIdRequest, error) {
 	uniqueIdentifier := wrapperspb.String(id)
 	enabled := wrapperspb.Bool(in.Active)
 	name := wrapperspb.String(in.Name)
@@ -1265,7 +1396,7 @@ func (in *AlertSpec) ExtractUpdateAlertRequest(ctx context.Context, log logr.Log
 	metaLabels := expandMetaLabels(in.Labels)
 	expirationDate := expandExpirationDate(in.ExpirationDate)
 	showInInsight := expandShowInInsight(in.ShowInInsight)
-	notificationGroups, err := expandNotificationGroups(ctx, log, in.NotificationGroups)
+	notificationGroups, err := expandNotificationGroups(ctx, in.NotificationGroups, webhooks)
 	if err != nil {
 		return nil, err
 	}
@@ -1333,9 +1464,15 @@ type Notification struct {
 
 	NotifyOn NotifyOn `json:"notifyOn,omitempty"`
 
+	// The name of the outbound webhook in Coralogix to notify. Prefer integrationRef for outbound webhooks managed by the
+	// operator, which don't depend on their display names.
 	// +optional
 	IntegrationName *string `json:"integrationName,omitempty"`
 
+	// The OutboundWebhook resource to notify.
+	// +optional
+	IntegrationRef *OutboundWebhookReference `json:"integrationRef,omitempty"`
+
 	// +optional
 	EmailRecipients []string `json:"emailRecipients,omitempty"`
 }
@@ -1357,6 +1494,14 @@ func (in *Notification) DeepEqual(actualNotification Notification) (bool, utils.
 		}
 	}
 
+	if !reflect.DeepEqual(in.IntegrationRef, actualNotification.IntegrationRef) {
+		return false, utils.Diff{
+			Name:    "IntegrationRef",
+			Desired: in.IntegrationRef,
+			Actual:  actualNotification.IntegrationRef,
+		}
+	}
+
 	if !utils.SlicesWithUniqueValuesEqual(in.EmailRecipients, actualNotification.EmailRecipients) {
 		return false, utils.Diff{
 			Name:    "EmailRecipients",
@@ -3100,6 +3245,8 @@ type AlertStatus struct {
 	Scheduling *Scheduling `json:"scheduling,omitempty"`
 
 	AlertType AlertType `json:"alertType,omitempty"`
+
+	SyncStatus `json:",inline"`
 }
 
 func NewDefaultAlertStatus() *AlertStatus {
@@ -3110,6 +3257,12 @@ func NewDefaultAlertStatus() *AlertStatus {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`,priority=1
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,priority=1
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:storageversion
 
 // Alert is the Schema for the alerts API
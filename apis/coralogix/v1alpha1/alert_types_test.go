@@ -1,6 +1,15 @@
 package v1alpha1
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
 
 func TestDeepEqualNotificationGroupsEquals(t *testing.T) {
 	integrationName := "WebhookAlerts"
@@ -186,3 +195,129 @@ func TestDeepEqualNotificationGroupsNotEquals(t *testing.T) {
 		t.Error("Expected to be not equal but got")
 	}
 }
+
+// staticWebhooks resolves the outbound webhooks by name from a map, without Coralogix.
+type staticWebhooks map[string]uint32
+
+func (w staticWebhooks) WebhookID(_ context.Context, name string) (uint32, bool, error) {
+	id, ok := w[name]
+	return id, ok, nil
+}
+
+func (w staticWebhooks) WebhookName(_ context.Context, id uint32) (string, bool, error) {
+	for name, webhookID := range w {
+		if webhookID == id {
+			return name, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func TestExpandNotificationsUnresolvedWebhook(t *testing.T) {
+	integrationName := "WebhookAlerts"
+	notifications := []Notification{
+		{
+			RetriggeringPeriodMinutes: 5,
+			NotifyOn:                  NotifyOnTriggeredOnly,
+			IntegrationName:           &integrationName,
+		},
+	}
+
+	ctx := context.Background()
+	if _, err := expandNotifications(ctx, notifications, NotificationWebhooks{Resolver: staticWebhooks{integrationName: 1}}); err != nil {
+		t.Fatalf("expected the webhook to be resolved, got %v", err)
+	}
+
+	_, err := expandNotifications(ctx, notifications, NotificationWebhooks{Resolver: staticWebhooks{}})
+	var unresolvedWebhook *UnresolvedWebhookError
+	if !errors.As(err, &unresolvedWebhook) || unresolvedWebhook.Name != integrationName {
+		t.Fatalf("expected an unresolved webhook error for %q, got %v", integrationName, err)
+	}
+}
+
+func TestExpandNotificationsIntegrationRef(t *testing.T) {
+	ref := OutboundWebhookReference{Name: "slack"}
+	notifications := []Notification{
+		{
+			RetriggeringPeriodMinutes: 5,
+			NotifyOn:                  NotifyOnTriggeredOnly,
+			IntegrationRef:            &ref,
+		},
+	}
+
+	ctx := context.Background()
+	expanded, err := expandNotifications(ctx, notifications, NotificationWebhooks{RefIDs: map[OutboundWebhookReference]uint32{ref: 7}})
+	if err != nil {
+		t.Fatalf("expected the integration ref to be resolved, got %v", err)
+	}
+	if id := expanded[0].GetIntegrationId().GetValue(); id != 7 {
+		t.Errorf("expected the external ID of the referenced webhook, got %d", id)
+	}
+
+	_, err = expandNotifications(ctx, notifications, NotificationWebhooks{})
+	var notReady *OutboundWebhookNotReadyError
+	if !errors.As(err, &notReady) || notReady.Ref != ref {
+		t.Fatalf("expected a not ready error for %v, got %v", ref, err)
+	}
+}
+
+func TestDeepEqualNotificationGroupsIntegrationRef(t *testing.T) {
+	notificationGroups := func(ref OutboundWebhookReference) []NotificationGroup {
+		return []NotificationGroup{{Notifications: []Notification{{IntegrationRef: &ref}}}}
+	}
+
+	if equal, diff := DeepEqualNotificationGroups(notificationGroups(OutboundWebhookReference{Name: "slack"}), notificationGroups(OutboundWebhookReference{Name: "slack"})); !equal {
+		t.Errorf("expected to be equal but got diff %+v", diff)
+	}
+	if equal, _ := DeepEqualNotificationGroups(notificationGroups(OutboundWebhookReference{Name: "slack"}), notificationGroups(OutboundWebhookReference{Name: "pagerduty"})); equal {
+		t.Error("expected notifications to other outbound webhooks to be not equal")
+	}
+}
+
+func TestResolveIntegrationRefs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	ready := &OutboundWebhook{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "monitoring"},
+		Status:     OutboundWebhookStatus{ExternalID: ptr.To("7")},
+	}
+	pending := &OutboundWebhook{ObjectMeta: metav1.ObjectMeta{Name: "pagerduty", Namespace: "monitoring"}}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ready, pending).Build()
+
+	alertWithRef := func(namespace string, ref OutboundWebhookReference) *Alert {
+		return &Alert{
+			ObjectMeta: metav1.ObjectMeta{Name: "alert", Namespace: namespace},
+			Spec: AlertSpec{NotificationGroups: []NotificationGroup{
+				{Notifications: []Notification{{IntegrationRef: &ref}}},
+			}},
+		}
+	}
+
+	for _, ref := range []OutboundWebhookReference{{Name: "slack"}, {Name: "slack", Namespace: "monitoring"}} {
+		ids, err := ResolveIntegrationRefs(context.Background(), reader, alertWithRef("monitoring", ref))
+		if err != nil {
+			t.Fatalf("expected %v to be resolved, got %v", ref, err)
+		}
+		if ids[ref] != 7 {
+			t.Errorf("expected the external ID of %v, got %v", ref, ids)
+		}
+	}
+
+	for _, alert := range []*Alert{
+		alertWithRef("monitoring", OutboundWebhookReference{Name: "pagerduty"}),
+		alertWithRef("monitoring", OutboundWebhookReference{Name: "missing"}),
+		alertWithRef("default", OutboundWebhookReference{Name: "slack"}),
+	} {
+		_, err := ResolveIntegrationRefs(context.Background(), reader, alert)
+		var notReady *OutboundWebhookNotReadyError
+		if !errors.As(err, &notReady) {
+			t.Errorf("expected a not ready error for %v, got %v", alert.Spec.IntegrationRefs(), err)
+		}
+	}
+
+	if ids, err := ResolveIntegrationRefs(context.Background(), nil, &Alert{}); ids != nil || err != nil {
+		t.Errorf("expected nothing to resolve without integration refs, got %v, %v", ids, err)
+	}
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
+	alerts "github.com/coralogix/coralogix-operator/controllers/clientset/grpc/alerts/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/mutate-coralogix-com-v1alpha1-alert,mutating=true,failurePolicy=fail,sideEffects=None,groups=coralogix.com,resources=alerts,verbs=create;update,versions=v1alpha1,name=malert.coralogix.com,admissionReviewVersions=v1
+
+// AlertDefaulter sets the defaults of alerts when they are applied, so the stored spec is the one sent to Coralogix.
+// +kubebuilder:object:generate=false
+type AlertDefaulter struct{}
+
+var _ admission.CustomDefaulter = &AlertDefaulter{}
+
+// SetupWebhookWithManager registers the defaulting webhook of alerts in the webhook server of mgr.
+func (d *AlertDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&Alert{}).
+		WithDefaulter(d).
+		Complete()
+}
+
+func (d *AlertDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	alert, ok := obj.(*Alert)
+	if !ok {
+		return fmt.Errorf("expected an Alert, got %T", obj)
+	}
+	alert.Spec.Default()
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-coralogix-com-v1alpha1-alert,mutating=false,failurePolicy=fail,sideEffects=None,groups=coralogix.com,resources=alerts,verbs=create;update,versions=v1alpha1,name=valert.coralogix.com,admissionReviewVersions=v1
+
+// AlertValidator rejects invalid alerts when they are applied, instead of failing to reconcile them later.
+// +kubebuilder:object:generate=false
+type AlertValidator struct {
+	// ClientSets resolves the account of an alert, to resolve the outbound webhooks it notifies.
+	// When nil, only the spec itself is validated.
+	ClientSets ClientSetResolver
+	// Client reads the OutboundWebhooks the notifications of alerts refer to. It is required along with ClientSets.
+	Client client.Reader
+	// ValidateRemotely also sends the alert to the ValidateAlert API of Coralogix.
+	ValidateRemotely bool
+}
+
+var _ admission.CustomValidator = &AlertValidator{}
+
+// SetupWebhookWithManager registers the validating webhook of alerts in the webhook server of mgr.
+func (v *AlertValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&Alert{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *AlertValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	alert, ok := obj.(*Alert)
+	if !ok {
+		return nil, fmt.Errorf("expected an Alert, got %T", obj)
+	}
+	return v.validate(ctx, alert)
+}
+
+func (v *AlertValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldAlert, ok := oldObj.(*Alert)
+	if !ok {
+		return nil, fmt.Errorf("expected an Alert, got %T", oldObj)
+	}
+	alert, ok := newObj.(*Alert)
+	if !ok {
+		return nil, fmt.Errorf("expected an Alert, got %T", newObj)
+	}
+
+	if skipsValidation(alert, oldAlert.Spec, alert.Spec) {
+		return nil, nil
+	}
+	return v.validate(ctx, alert)
+}
+
+func (v *AlertValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *AlertValidator) validate(ctx context.Context, alert *Alert) (admission.Warnings, error) {
+	if err := invalid("Alert", alert.Name, alert.Spec.Validate(field.NewPath("spec"))); err != nil {
+		return nil, err
+	}
+
+	if v.ClientSets == nil {
+		return nil, nil
+	}
+
+	// Coralogix being unreachable must not block applying alerts, which are reconciled once it is back.
+	clientSet, err := v.ClientSets.ClientSet(ctx, alert.Namespace, alert.Spec.AccountRef)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("the alert was not validated against Coralogix: %v", err)}, nil
+	}
+
+	integrationRefIDs, err := ResolveIntegrationRefs(ctx, v.Client, alert)
+	if err != nil {
+		// The outbound webhooks may be applied along with the alert, so the alert is reconciled once they are ready.
+		return admission.Warnings{err.Error()}, nil
+	}
+
+	createRequest, err := alert.ExtractCreateAlertRequest(ctx, NotificationWebhooks{
+		Resolver: clientset.NewWebhookResolver(clientSet.OutboundWebhooks(), 0),
+		RefIDs:   integrationRefIDs,
+	})
+	if err != nil {
+		var unresolvedWebhook *UnresolvedWebhookError
+		if errors.As(err, &unresolvedWebhook) {
+			// The outbound webhook may be applied along with the alert, so the alert is reconciled once it exists.
+			return admission.Warnings{unresolvedWebhook.Error()}, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return admission.Warnings{fmt.Sprintf("the alert was not validated against Coralogix: %v", err)}, nil
+		}
+		return nil, invalid("Alert", alert.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "notificationGroups"), alert.Spec.NotificationGroups, err.Error()),
+		})
+	}
+
+	if !v.ValidateRemotely {
+		return nil, nil
+	}
+
+	_, err = clientSet.Alerts().ValidateAlert(ctx, &alerts.ValidateAlertRequest{Alert: alertFromCreateRequest(createRequest)})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil, nil
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return nil, fmt.Errorf("the alert was rejected by Coralogix: %s", status.Convert(err).Message())
+	default:
+		return admission.Warnings{fmt.Sprintf("the alert was not validated against Coralogix: %v", err)}, nil
+	}
+}
+
+func alertFromCreateRequest(req *alerts.CreateAlertRequest) *alerts.Alert {
+	return &alerts.Alert{
+		Name:                       req.Name,
+		Description:                req.Description,
+		IsActive:                   req.IsActive,
+		Severity:                   req.Severity,
+		Expiration:                 req.Expiration,
+		Condition:                  req.Condition,
+		ShowInInsight:              req.ShowInInsight,
+		NotificationGroups:         req.NotificationGroups,
+		Filters:                    req.Filters,
+		ActiveWhen:                 req.ActiveWhen,
+		NotificationPayloadFilters: req.NotificationPayloadFilters,
+		MetaLabels:                 req.MetaLabels,
+		MetaLabelsStrings:          req.MetaLabelsStrings,
+		TracingAlert:               req.TracingAlert,
+	}
+}
+
+// Default sets the fields of the spec left empty to the values Coralogix would otherwise assume.
+// It is idempotent, so alerts admitted before the defaulting webhook was enabled can be defaulted when reconciled.
+func (in *AlertSpec) Default() {
+	if in.Labels == nil {
+		in.Labels = make(map[string]string)
+	}
+	if in.Labels[ManagedByLabelKey] == "" {
+		in.Labels[ManagedByLabelKey] = ManagedByLabelValue
+	}
+
+	for i := range in.NotificationGroups {
+		for j := range in.NotificationGroups[i].Notifications {
+			notification := &in.NotificationGroups[i].Notifications[j]
+			if notification.NotifyOn == "" {
+				notification.NotifyOn = NotifyOnTriggeredOnly
+			}
+			if notification.RetriggeringPeriodMinutes == 0 {
+				notification.RetriggeringPeriodMinutes = DefaultRetriggeringPeriodMinutes
+			}
+		}
+	}
+
+	if in.ShowInInsight != nil && in.ShowInInsight.NotifyOn == "" {
+		in.ShowInInsight.NotifyOn = NotifyOnTriggeredOnly
+	}
+
+	if in.Scheduling != nil && in.Scheduling.TimeZone == "" {
+		in.Scheduling.TimeZone = DefaultTimeZone
+	}
+}
+
+// Validate returns the errors of the spec that are not covered by the schema of the CRD, with their field paths.
+func (in *AlertSpec) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, in.AlertType.validate(path.Child("alertType"))...)
+
+	for i, group := range in.NotificationGroups {
+		for j, notification := range group.Notifications {
+			notificationPath := path.Child("notificationGroups").Index(i).Child("notifications").Index(j)
+			hasName, hasRef := notification.IntegrationName != nil, notification.IntegrationRef != nil
+			hasIntegration, hasRecipients := hasName || hasRef, len(notification.EmailRecipients) > 0
+			switch {
+			case hasName && hasRef:
+				errs = append(errs, field.Forbidden(notificationPath.Child("integrationRef"), "may not be set along with integrationName"))
+			case hasIntegration && hasRecipients:
+				errs = append(errs, field.Forbidden(notificationPath.Child("emailRecipients"), "may not be set along with integrationName or integrationRef"))
+			case !hasIntegration && !hasRecipients:
+				errs = append(errs, field.Required(notificationPath, "one of integrationName, integrationRef or emailRecipients must be set"))
+			}
+		}
+	}
+
+	return errs
+}
+
+func (in *AlertType) validate(path *field.Path) field.ErrorList {
+	if err := validateExactlyOne(path, map[string]bool{
+		"standard":     in.Standard != nil,
+		"ratio":        in.Ratio != nil,
+		"newValue":     in.NewValue != nil,
+		"uniqueCount":  in.UniqueCount != nil,
+		"timeRelative": in.TimeRelative != nil,
+		"metric":       in.Metric != nil,
+		"tracing":      in.Tracing != nil,
+		"flow":         in.Flow != nil,
+	}); err != nil {
+		return field.ErrorList{err}
+	}
+
+	var errs field.ErrorList
+	validateTimeWindow := func(timeWindowPath *field.Path, timeWindow string) {
+		if _, ok := AlertSchemaTimeWindowToProtoTimeWindow[timeWindow]; !ok {
+			errs = append(errs, field.NotSupported(timeWindowPath, timeWindow, supportedTimeWindows()))
+		}
+	}
+
+	switch {
+	case in.Standard != nil:
+		if timeWindow := in.Standard.Conditions.TimeWindow; timeWindow != nil {
+			validateTimeWindow(path.Child("standard", "conditions", "timeWindow"), string(*timeWindow))
+		}
+	case in.Ratio != nil:
+		validateTimeWindow(path.Child("ratio", "conditions", "timeWindow"), string(in.Ratio.Conditions.TimeWindow))
+	case in.NewValue != nil:
+		validateTimeWindow(path.Child("newValue", "conditions", "timeWindow"), string(in.NewValue.Conditions.TimeWindow))
+	case in.UniqueCount != nil:
+		validateTimeWindow(path.Child("uniqueCount", "conditions", "timeWindow"), string(in.UniqueCount.Conditions.TimeWindow))
+	case in.TimeRelative != nil:
+		timeWindow := in.TimeRelative.Conditions.TimeWindow
+		if _, ok := AlertSchemaRelativeTimeFrameToProtoTimeFrameAndRelativeTimeFrame[timeWindow]; !ok {
+			errs = append(errs, field.NotSupported(path.Child("timeRelative", "conditions", "timeWindow"), timeWindow, supportedRelativeTimeWindows()))
+		}
+	case in.Metric != nil:
+		metricPath := path.Child("metric")
+		switch {
+		case in.Metric.Lucene != nil && in.Metric.Promql != nil:
+			errs = append(errs, field.Forbidden(metricPath.Child("promql"), "may not be set along with lucene"))
+		case in.Metric.Lucene != nil:
+			validateTimeWindow(metricPath.Child("lucene", "conditions", "timeWindow"), string(in.Metric.Lucene.Conditions.TimeWindow))
+		case in.Metric.Promql != nil:
+			validateTimeWindow(metricPath.Child("promql", "conditions", "timeWindow"), string(in.Metric.Promql.Conditions.TimeWindow))
+		default:
+			errs = append(errs, field.Required(metricPath, "one of lucene or promql must be set"))
+		}
+	case in.Tracing != nil:
+		if timeWindow := in.Tracing.Conditions.TimeWindow; timeWindow != nil {
+			validateTimeWindow(path.Child("tracing", "conditions", "timeWindow"), string(*timeWindow))
+		}
+	}
+
+	return errs
+}
+
+func supportedTimeWindows() []string {
+	timeWindows := make([]string, 0, len(AlertSchemaTimeWindowToProtoTimeWindow))
+	for timeWindow := range AlertSchemaTimeWindowToProtoTimeWindow {
+		timeWindows = append(timeWindows, timeWindow)
+	}
+	sort.Strings(timeWindows)
+	return timeWindows
+}
+
+func supportedRelativeTimeWindows() []string {
+	timeWindows := make([]string, 0, len(AlertSchemaRelativeTimeFrameToProtoTimeFrameAndRelativeTimeFrame))
+	for timeWindow := range AlertSchemaRelativeTimeFrameToProtoTimeFrameAndRelativeTimeFrame {
+		timeWindows = append(timeWindows, string(timeWindow))
+	}
+	sort.Strings(timeWindows)
+	return timeWindows
+}
@@ -0,0 +1,163 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validAlertSpec() AlertSpec {
+	integrationName := "WebhookAlerts"
+	return AlertSpec{
+		Name:     "alert",
+		Severity: AlertSeverityInfo,
+		AlertType: AlertType{
+			NewValue: &NewValue{
+				Conditions: NewValueConditions{Key: "status", TimeWindow: "TwelveHours"},
+			},
+		},
+		NotificationGroups: []NotificationGroup{
+			{Notifications: []Notification{{IntegrationName: &integrationName}}},
+		},
+	}
+}
+
+func TestAlertSpecValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		mutate    func(spec *AlertSpec)
+		wantField string
+	}{
+		{
+			name:   "valid",
+			mutate: func(spec *AlertSpec) {},
+		},
+		{
+			name: "more than one alert type",
+			mutate: func(spec *AlertSpec) {
+				spec.AlertType.Flow = &Flow{}
+			},
+			wantField: "spec.alertType",
+		},
+		{
+			name: "no alert type",
+			mutate: func(spec *AlertSpec) {
+				spec.AlertType = AlertType{}
+			},
+			wantField: "spec.alertType",
+		},
+		{
+			name: "unknown time window",
+			mutate: func(spec *AlertSpec) {
+				spec.AlertType.NewValue.Conditions.TimeWindow = "Week"
+			},
+			wantField: "spec.alertType.newValue.conditions.timeWindow",
+		},
+		{
+			name: "both integration name and email recipients",
+			mutate: func(spec *AlertSpec) {
+				spec.NotificationGroups[0].Notifications[0].EmailRecipients = []string{"oncall@example.com"}
+			},
+			wantField: "spec.notificationGroups[0].notifications[0].emailRecipients",
+		},
+		{
+			name: "both integration name and integration ref",
+			mutate: func(spec *AlertSpec) {
+				spec.NotificationGroups[0].Notifications[0].IntegrationRef = &OutboundWebhookReference{Name: "slack"}
+			},
+			wantField: "spec.notificationGroups[0].notifications[0].integrationRef",
+		},
+		{
+			name: "integration ref",
+			mutate: func(spec *AlertSpec) {
+				spec.NotificationGroups[0].Notifications[0].IntegrationName = nil
+				spec.NotificationGroups[0].Notifications[0].IntegrationRef = &OutboundWebhookReference{Name: "slack"}
+			},
+		},
+		{
+			name: "neither integration name nor email recipients",
+			mutate: func(spec *AlertSpec) {
+				spec.NotificationGroups[0].Notifications[0].IntegrationName = nil
+			},
+			wantField: "spec.notificationGroups[0].notifications[0]",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := validAlertSpec()
+			tt.mutate(&spec)
+
+			errs := spec.Validate(field.NewPath("spec"))
+			if tt.wantField == "" {
+				if len(errs) > 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Field != tt.wantField {
+				t.Fatalf("expected a single error on %s, got %v", tt.wantField, errs)
+			}
+		})
+	}
+}
+
+func TestAlertValidator(t *testing.T) {
+	validator := &AlertValidator{}
+	valid := &Alert{ObjectMeta: metav1.ObjectMeta{Name: "alert"}, Spec: validAlertSpec()}
+	invalid := valid.DeepCopy()
+	invalid.Spec.AlertType.NewValue.Conditions.TimeWindow = "Month"
+
+	if _, err := validator.ValidateCreate(context.Background(), valid); err != nil {
+		t.Errorf("expected a valid alert to be admitted, got %v", err)
+	}
+	if _, err := validator.ValidateCreate(context.Background(), invalid); !apierrors.IsInvalid(err) {
+		t.Errorf("expected an invalid alert to be rejected, got %v", err)
+	}
+	if _, err := validator.ValidateUpdate(context.Background(), valid, invalid); !apierrors.IsInvalid(err) {
+		t.Errorf("expected an invalid spec change to be rejected, got %v", err)
+	}
+
+	// Existing alerts must remain deletable, whatever their spec.
+	deleted := invalid.DeepCopy()
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+	deleted.Finalizers = nil
+	if _, err := validator.ValidateUpdate(context.Background(), invalid, deleted); err != nil {
+		t.Errorf("expected an update leaving the spec as is to be admitted, got %v", err)
+	}
+}
+
+func TestAlertSpecDefault(t *testing.T) {
+	spec := validAlertSpec()
+	spec.Scheduling = &Scheduling{}
+	spec.ShowInInsight = &ShowInInsight{RetriggeringPeriodMinutes: 10}
+	spec.NotificationGroups = append(spec.NotificationGroups, NotificationGroup{
+		Notifications: []Notification{{EmailRecipients: []string{"oncall@example.com"}, NotifyOn: NotifyOnTriggeredAndResolved, RetriggeringPeriodMinutes: 60}},
+	})
+	spec.Default()
+
+	if spec.Labels[ManagedByLabelKey] != ManagedByLabelValue {
+		t.Errorf("expected the %s label to be set, got %v", ManagedByLabelKey, spec.Labels)
+	}
+	if got := spec.NotificationGroups[0].Notifications[0]; got.NotifyOn != NotifyOnTriggeredOnly || got.RetriggeringPeriodMinutes != DefaultRetriggeringPeriodMinutes {
+		t.Errorf("expected the notification to be defaulted, got %+v", got)
+	}
+	if got := spec.NotificationGroups[1].Notifications[0]; got.NotifyOn != NotifyOnTriggeredAndResolved || got.RetriggeringPeriodMinutes != 60 {
+		t.Errorf("expected the notification to be kept, got %+v", got)
+	}
+	if spec.ShowInInsight.NotifyOn != NotifyOnTriggeredOnly {
+		t.Errorf("expected notifyOn of showInInsight to be defaulted, got %q", spec.ShowInInsight.NotifyOn)
+	}
+	if spec.Scheduling.TimeZone != DefaultTimeZone {
+		t.Errorf("expected the time zone to be defaulted, got %q", spec.Scheduling.TimeZone)
+	}
+
+	defaulted := spec.DeepCopy()
+	spec.Default()
+	if !equality.Semantic.DeepEqual(defaulted, &spec) {
+		t.Error("expected defaulting to be idempotent")
+	}
+}
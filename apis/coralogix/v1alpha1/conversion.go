@@ -0,0 +1,26 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// The controllers work with v1alpha1, which is the version the other versions are converted to and from.
+
+func (*Alert) Hub()                   {}
+func (*RuleGroup) Hub()               {}
+func (*RecordingRuleGroupSet) Hub()   {}
+func (*OutboundWebhook) Hub()         {}
+func (*CoralogixAccount) Hub()        {}
+func (*ClusterCoralogixAccount) Hub() {}
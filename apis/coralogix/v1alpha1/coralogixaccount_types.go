@@ -0,0 +1,142 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CoralogixAccountKind        = "CoralogixAccount"
+	ClusterCoralogixAccountKind = "ClusterCoralogixAccount"
+)
+
+// CoralogixAccountSpec defines the Coralogix team an account refers to and how to authenticate against it.
+// +kubebuilder:validation:XValidation:rule="has(self.region) != has(self.domain)",message="exactly one of region or domain must be set"
+type CoralogixAccountSpec struct {
+	// The region of the Coralogix team.
+	// +optional
+	// +kubebuilder:validation:Enum=APAC1;AP1;APAC2;AP2;EUROPE1;EU1;EUROPE2;EU2;USA1;US1;USA2;US2
+	Region *string `json:"region,omitempty"`
+
+	// The domain of the Coralogix team, for private domains.
+	// +optional
+	Domain *string `json:"domain,omitempty"`
+
+	// The Secret key holding the api-key of the Coralogix team.
+	APIKeySecretRef AccountSecretKeySelector `json:"apiKeySecretRef"`
+}
+
+// AccountSecretKeySelector selects a key of a Secret.
+type AccountSecretKeySelector struct {
+	// The name of the Secret.
+	Name string `json:"name"`
+
+	// The key of the Secret holding the api-key.
+	Key string `json:"key"`
+
+	// The namespace of the Secret. Required for a ClusterCoralogixAccount,
+	// and ignored for a CoralogixAccount, whose Secret has to be in its own namespace.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// CoralogixAccountStatus defines the observed state of CoralogixAccount
+type CoralogixAccountStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// CoralogixAccount is the Schema for the coralogixaccounts API.
+// It describes a Coralogix team that namespaced resources can refer to with spec.accountRef.
+type CoralogixAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CoralogixAccountSpec   `json:"spec,omitempty"`
+	Status CoralogixAccountStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CoralogixAccountList contains a list of CoralogixAccount
+type CoralogixAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CoralogixAccount `json:"items"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// ClusterCoralogixAccount is the Schema for the clustercoralogixaccounts API.
+// It describes a Coralogix team that resources of any namespace can refer to with spec.accountRef.
+type ClusterCoralogixAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CoralogixAccountSpec   `json:"spec,omitempty"`
+	Status CoralogixAccountStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterCoralogixAccountList contains a list of ClusterCoralogixAccount
+type ClusterCoralogixAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterCoralogixAccount `json:"items"`
+}
+
+// AccountReference refers to the CoralogixAccount or ClusterCoralogixAccount a resource is managed in.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="accountRef is immutable"
+type AccountReference struct {
+	// The kind of the referenced account.
+	// +optional
+	// +kubebuilder:default=CoralogixAccount
+	// +kubebuilder:validation:Enum=CoralogixAccount;ClusterCoralogixAccount
+	Kind string `json:"kind,omitempty"`
+
+	// The name of the referenced account. A CoralogixAccount has to be in the namespace of the referring resource.
+	Name string `json:"name"`
+}
+
+// AccountNotFoundError is returned when the account a resource refers to doesn't exist.
+// +kubebuilder:object:generate=false
+type AccountNotFoundError struct {
+	Kind string
+	Name string
+	Err  error
+}
+
+func (e *AccountNotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found: %s", e.Kind, e.Name, e.Err)
+}
+
+func (e *AccountNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func init() {
+	SchemeBuilder.Register(&CoralogixAccount{}, &CoralogixAccountList{}, &ClusterCoralogixAccount{}, &ClusterCoralogixAccountList{})
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "fmt"
+
+// DeletionPolicy defines what the operator does with the remote object when the resource is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the remote object with the resource.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain leaves the remote object in Coralogix, no longer managed by the operator.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// DeletionPolicies are the valid values of DeletionPolicy.
+var DeletionPolicies = []DeletionPolicy{DeletionPolicyDelete, DeletionPolicyRetain}
+
+// ParseDeletionPolicy parses one of DeletionPolicies.
+func ParseDeletionPolicy(value string) (DeletionPolicy, error) {
+	for _, policy := range DeletionPolicies {
+		if DeletionPolicy(value) == policy {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid deletion policy %q: should be one of %q", value, DeletionPolicies)
+}
+
+// Retains reports whether the policy keeps the remote object when the resource is deleted.
+// An unset policy falls back to defaultPolicy.
+func (p *DeletionPolicy) Retains(defaultPolicy DeletionPolicy) bool {
+	if p == nil {
+		return defaultPolicy == DeletionPolicyRetain
+	}
+	return *p == DeletionPolicyRetain
+}
@@ -0,0 +1,30 @@
+package v1alpha1
+
+import "testing"
+
+func TestParseDeletionPolicy(t *testing.T) {
+	if policy, err := ParseDeletionPolicy("Retain"); err != nil || policy != DeletionPolicyRetain {
+		t.Errorf("ParseDeletionPolicy(Retain) = %v, %v", policy, err)
+	}
+	if _, err := ParseDeletionPolicy("retain"); err == nil {
+		t.Error("deletion policies should be case sensitive")
+	}
+}
+
+func TestDeletionPolicyRetains(t *testing.T) {
+	retain, del := DeletionPolicyRetain, DeletionPolicyDelete
+	for _, tt := range []struct {
+		policy        *DeletionPolicy
+		defaultPolicy DeletionPolicy
+		want          bool
+	}{
+		{policy: nil, defaultPolicy: DeletionPolicyDelete, want: false},
+		{policy: nil, defaultPolicy: DeletionPolicyRetain, want: true},
+		{policy: &retain, defaultPolicy: DeletionPolicyDelete, want: true},
+		{policy: &del, defaultPolicy: DeletionPolicyRetain, want: false},
+	} {
+		if got := tt.policy.Retains(tt.defaultPolicy); got != tt.want {
+			t.Errorf("Retains(%v) of %v = %v, want %v", tt.defaultPolicy, tt.policy, got, tt.want)
+		}
+	}
+}
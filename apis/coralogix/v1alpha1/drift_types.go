@@ -0,0 +1,92 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	utils "github.com/coralogix/coralogix-operator/apis"
+)
+
+// DriftPolicy defines what the operator does when the remote object differs from the spec.
+// +kubebuilder:validation:Enum=Enforce;Report
+type DriftPolicy string
+
+const (
+	// DriftPolicyEnforce overwrites the remote object with the spec.
+	DriftPolicyEnforce DriftPolicy = "Enforce"
+	// DriftPolicyReport only records the difference in the status, leaving the remote object as is.
+	DriftPolicyReport DriftPolicy = "Report"
+)
+
+// Drift is a difference found between the spec and the remote object.
+type Drift struct {
+	// The path of the field that differs.
+	Field string `json:"field"`
+
+	// The value of the field in the spec.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+
+	// The value of the field in the remote object.
+	// +optional
+	Actual string `json:"actual,omitempty"`
+
+	// The time the difference was detected.
+	DetectedAt metav1.Time `json:"detectedAt"`
+
+	// Whether the spec was applied over the difference, according to the drift policy.
+	// +optional
+	Enforced bool `json:"enforced,omitempty"`
+}
+
+// NewDrift returns the Drift of diff, detected now.
+func NewDrift(diff utils.Diff, enforced bool) *Drift {
+	return &Drift{
+		Field:      diff.Name,
+		Desired:    formatDiffValue(diff.Desired),
+		Actual:     formatDiffValue(diff.Actual),
+		DetectedAt: metav1.Now(),
+		Enforced:   enforced,
+	}
+}
+
+// Enforces reports whether the policy overwrites the remote object with the spec. The default policy is Enforce.
+func (p *DriftPolicy) Enforces() bool {
+	return p == nil || *p != DriftPolicyReport
+}
+
+// SameAs reports whether d describes the same difference as other, regardless of when they were detected.
+func (d *Drift) SameAs(other *Drift) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+	return d.Field == other.Field && d.Desired == other.Desired && d.Actual == other.Actual && d.Enforced == other.Enforced
+}
+
+func formatDiffValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if b, err := json.Marshal(value); err == nil {
+		return string(b)
+	}
+	return fmt.Sprintf("%v", value)
+}
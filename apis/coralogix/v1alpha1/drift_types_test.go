@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	utils "github.com/coralogix/coralogix-operator/apis"
+)
+
+func TestNewDrift(t *testing.T) {
+	priority := "P1"
+	drift := NewDrift(utils.Diff{
+		Name:    "NotificationGroups.0.Notifications.0.IntegrationName",
+		Desired: &priority,
+		Actual:  nil,
+	}, true)
+
+	if drift.Field != "NotificationGroups.0.Notifications.0.IntegrationName" {
+		t.Errorf("unexpected field %q", drift.Field)
+	}
+	if drift.Desired != `"P1"` {
+		t.Errorf("pointers should be formatted by value, got %q", drift.Desired)
+	}
+	if drift.Actual != "" {
+		t.Errorf("nil should be formatted as an empty string, got %q", drift.Actual)
+	}
+	if drift.DetectedAt.IsZero() {
+		t.Error("detection time should be set")
+	}
+	if !drift.Enforced {
+		t.Error("drift should be enforced")
+	}
+}
+
+func TestDriftPolicyEnforces(t *testing.T) {
+	enforce, report := DriftPolicyEnforce, DriftPolicyReport
+	for _, tt := range []struct {
+		policy *DriftPolicy
+		want   bool
+	}{
+		{policy: nil, want: true},
+		{policy: &enforce, want: true},
+		{policy: &report, want: false},
+	} {
+		if got := tt.policy.Enforces(); got != tt.want {
+			t.Errorf("Enforces() of %v = %v, want %v", tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestDriftSameAs(t *testing.T) {
+	diff := utils.Diff{Name: "Severity", Desired: "Info", Actual: "Critical"}
+	first, second := NewDrift(diff, false), NewDrift(diff, false)
+	second.DetectedAt.Time = second.DetectedAt.Add(time.Minute)
+
+	if !first.SameAs(second) {
+		t.Error("drifts of the same difference should be the same, regardless of detection time")
+	}
+	if first.SameAs(NewDrift(diff, true)) {
+		t.Error("an enforced drift should differ from a reported one")
+	}
+	if first.SameAs(nil) || !(*Drift)(nil).SameAs(nil) {
+		t.Error("only nil drifts should be the same as nil")
+	}
+}
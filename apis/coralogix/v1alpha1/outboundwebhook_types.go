@@ -18,25 +18,48 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 
 	gouuid "github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
 
 	utils "github.com/coralogix/coralogix-operator/apis"
 )
 
+// invalidSecretKeyCharacters are the characters of header names that Secret keys can't have.
+var invalidSecretKeyCharacters = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // OutboundWebhookSpec defines the desired state of OutboundWebhook
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef)",message="accountRef can't be added or removed"
 type OutboundWebhookSpec struct {
 	//+kubebuilder:validation:MinLength=0
 	Name string `json:"name"`
 
 	OutboundWebhookType OutboundWebhookType `json:"outboundWebhookType"`
+
+	// The Coralogix account the resource is managed in. Defaults to the account the operator was started with.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+
+	// What to do when the remote outbound webhook is changed outside of the operator, e.g. in the Coralogix UI.
+	// Enforce overwrites the remote outbound webhook with the spec, and Report only records the difference in status.lastDrift.
+	// +optional
+	// +kubebuilder:default=Enforce
+	DriftPolicy *DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// What to do with the remote outbound webhook when the resource is deleted. Delete deletes it, and Retain leaves it in
+	// Coralogix, e.g. to move the resource to another namespace. Defaults to the operator's deletion policy.
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 type OutboundWebhookType struct {
@@ -187,6 +210,179 @@ func (in *OutboundWebhookType) DeepEqual(webhookType *OutboundWebhookTypeStatus)
 	return true, utils.Diff{}
 }
 
+// secretFields returns the sensitive fields of the outbound webhook type, except for the headers of generic webhooks.
+func (in *OutboundWebhookType) secretFields() []secretField {
+	var fields []secretField
+	if in.Slack != nil {
+		fields = append(fields, secretField{parent: "slack", name: "url", value: &in.Slack.Url, from: &in.Slack.UrlFrom})
+	}
+	if in.PagerDuty != nil {
+		fields = append(fields, secretField{parent: "pagerDuty", name: "serviceKey", value: &in.PagerDuty.ServiceKey, from: &in.PagerDuty.ServiceKeyFrom})
+	}
+	if in.MicrosoftTeams != nil {
+		fields = append(fields, secretField{parent: "microsoftTeams", name: "url", value: &in.MicrosoftTeams.Url, from: &in.MicrosoftTeams.UrlFrom})
+	}
+	if in.Jira != nil {
+		fields = append(fields, secretField{parent: "jira", name: "apiToken", value: &in.Jira.ApiToken, from: &in.Jira.ApiTokenFrom})
+	}
+	if in.Opsgenie != nil {
+		fields = append(fields, secretField{parent: "opsgenie", name: "url", value: &in.Opsgenie.Url, from: &in.Opsgenie.UrlFrom})
+	}
+	if in.Demisto != nil {
+		fields = append(fields, secretField{parent: "demisto", name: "url", value: &in.Demisto.Url, from: &in.Demisto.UrlFrom})
+	}
+	return fields
+}
+
+// SecretNames returns the names of the Secrets the outbound webhook type takes values from.
+func (in *OutboundWebhookType) SecretNames() []string {
+	var names []string
+	for _, f := range in.secretFields() {
+		if *f.from != nil && (*f.from).SecretKeyRef != nil {
+			names = append(names, (*f.from).SecretKeyRef.Name)
+		}
+	}
+	if in.GenericWebhook != nil {
+		for _, from := range in.GenericWebhook.HeadersFrom {
+			if from.SecretKeyRef != nil {
+				names = append(names, from.SecretKeyRef.Name)
+			}
+		}
+	}
+	return names
+}
+
+// ResolveSecrets returns a copy of the outbound webhook type with the values of its Secret keys, read with getValue,
+// in place of the references to them.
+func (in *OutboundWebhookType) ResolveSecrets(getValue func(*corev1.SecretKeySelector) (string, error)) (*OutboundWebhookType, error) {
+	value := func(from *ValueFrom) (string, error) {
+		if from.SecretKeyRef == nil {
+			return "", fmt.Errorf("secretKeyRef is not set")
+		}
+		return getValue(from.SecretKeyRef)
+	}
+
+	out := in.DeepCopy()
+	for _, f := range out.secretFields() {
+		if *f.from == nil {
+			continue
+		}
+		v, err := value(*f.from)
+		if err != nil {
+			return nil, fmt.Errorf("error on resolving %s.%s: %w", f.parent, f.fromName(), err)
+		}
+		*f.value, *f.from = v, nil
+	}
+
+	if genericWebhook := out.GenericWebhook; genericWebhook != nil && len(genericWebhook.HeadersFrom) > 0 {
+		headers := make(map[string]string, len(genericWebhook.Headers)+len(genericWebhook.HeadersFrom))
+		for name, v := range genericWebhook.Headers {
+			headers[name] = v
+		}
+		for name, from := range genericWebhook.HeadersFrom {
+			v, err := value(&from)
+			if err != nil {
+				return nil, fmt.Errorf("error on resolving genericWebhook.headersFrom.%s: %w", name, err)
+			}
+			headers[name] = v
+		}
+		genericWebhook.Headers, genericWebhook.HeadersFrom = headers, nil
+	}
+
+	return out, nil
+}
+
+// ReferSecret returns a copy of the outbound webhook type taking its sensitive values, including all the headers of
+// generic webhooks, from the keys of the Secret secretName instead of holding them, and the keys the Secret should have.
+// The values already taken from Secrets are kept as they are.
+func (in *OutboundWebhookType) ReferSecret(secretName string) (*OutboundWebhookType, []string) {
+	ref := func(key string) *ValueFrom {
+		return &ValueFrom{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  key,
+		}}
+	}
+
+	out := in.DeepCopy()
+	var keys []string
+	for _, f := range out.secretFields() {
+		if *f.from != nil || *f.value == "" {
+			continue
+		}
+		*f.value, *f.from = "", ref(f.name)
+		keys = append(keys, f.name)
+	}
+
+	if genericWebhook := out.GenericWebhook; genericWebhook != nil && len(genericWebhook.Headers) > 0 {
+		names := make([]string, 0, len(genericWebhook.Headers))
+		for name := range genericWebhook.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if genericWebhook.HeadersFrom == nil {
+			genericWebhook.HeadersFrom = make(map[string]ValueFrom, len(names))
+		}
+		for _, name := range names {
+			key := invalidSecretKeyCharacters.ReplaceAllString(name, "-")
+			genericWebhook.HeadersFrom[name] = *ref(key)
+			keys = append(keys, key)
+		}
+		genericWebhook.Headers = nil
+	}
+
+	return out, keys
+}
+
+// HashSecrets returns a copy of the outbound webhook type with the hashes of its sensitive values, the way they are
+// shown in the status. The Secrets it refers to are expected to be resolved already.
+func (in *OutboundWebhookType) HashSecrets() *OutboundWebhookType {
+	out := in.DeepCopy()
+	for _, f := range out.secretFields() {
+		*f.value = HashSecret(*f.value)
+	}
+	if out.GenericWebhook != nil {
+		out.GenericWebhook.Headers = hashHeaders(out.GenericWebhook.Headers)
+	}
+	return out
+}
+
+// HashSecrets replaces the sensitive values of the status with their hashes, so the status doesn't show the credentials
+// of the remote outbound webhook and can still be compared with the hashed spec.
+func (in *OutboundWebhookTypeStatus) HashSecrets() {
+	if in == nil {
+		return
+	}
+
+	if in.GenericWebhook != nil {
+		in.GenericWebhook.Headers = hashHeaders(in.GenericWebhook.Headers)
+	}
+	// The other types of the status are the ones of the spec, so they have the same sensitive fields.
+	statusType := &OutboundWebhookType{
+		Slack:          in.Slack,
+		PagerDuty:      in.PagerDuty,
+		MicrosoftTeams: in.MicrosoftTeams,
+		Jira:           in.Jira,
+		Opsgenie:       in.Opsgenie,
+		Demisto:        in.Demisto,
+	}
+	for _, f := range statusType.secretFields() {
+		*f.value = HashSecret(*f.value)
+	}
+}
+
+// hashHeaders returns the headers of a generic webhook with hashed values, as any of them may hold credentials.
+func hashHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	hashed := make(map[string]string, len(headers))
+	for name, value := range headers {
+		hashed[name] = HashSecret(value)
+	}
+	return hashed
+}
+
 type GenericWebhook struct {
 	Url string `json:"url"`
 
@@ -195,6 +391,10 @@ type GenericWebhook struct {
 	// +optional
 	Headers map[string]string `json:"headers"`
 
+	// Headers taking their values from Secrets, e.g. Authorization. A header can't be in both headers and headersFrom.
+	// +optional
+	HeadersFrom map[string]ValueFrom `json:"headersFrom,omitempty"`
+
 	// +optional
 	Payload *string `json:"payload"`
 }
@@ -293,7 +493,11 @@ type Slack struct {
 	Digests []SlackConfigDigest `json:"digests"`
 	// +optional
 	Attachments []SlackConfigAttachment `json:"attachments"`
-	Url         string                  `json:"url"`
+	// Exactly one of url and urlFrom must be set.
+	// +optional
+	Url string `json:"url"`
+	// +optional
+	UrlFrom *ValueFrom `json:"urlFrom,omitempty"`
 }
 
 func (in *Slack) extractSlackConfig() *cxsdk.SlackWebhookInputData {
@@ -406,7 +610,11 @@ var (
 )
 
 type PagerDuty struct {
+	// Exactly one of serviceKey and serviceKeyFrom must be set.
+	// +optional
 	ServiceKey string `json:"serviceKey"`
+	// +optional
+	ServiceKeyFrom *ValueFrom `json:"serviceKeyFrom,omitempty"`
 }
 
 func (in *PagerDuty) extractPagerDutyConfig() *cxsdk.PagerDutyWebhookInputData {
@@ -518,7 +726,11 @@ func (in *EmailGroup) extractEmailGroupConfig() *cxsdk.EmailGroupWebhookInputDat
 }
 
 type MicrosoftTeams struct {
+	// Exactly one of url and urlFrom must be set.
+	// +optional
 	Url string `json:"url"`
+	// +optional
+	UrlFrom *ValueFrom `json:"urlFrom,omitempty"`
 }
 
 func (in *MicrosoftTeams) extractMicrosoftTeamsConfig() *cxsdk.MicrosoftTeamsWebhookInputData {
@@ -548,10 +760,14 @@ func (in *MicrosoftTeams) DeepEqual(microsoftTeams *MicrosoftTeams) (bool, utils
 }
 
 type Jira struct {
-	ApiToken   string `json:"apiToken"`
-	Email      string `json:"email"`
-	ProjectKey string `json:"projectKey"`
-	Url        string `json:"url"`
+	// Exactly one of apiToken and apiTokenFrom must be set.
+	// +optional
+	ApiToken string `json:"apiToken"`
+	// +optional
+	ApiTokenFrom *ValueFrom `json:"apiTokenFrom,omitempty"`
+	Email        string     `json:"email"`
+	ProjectKey   string     `json:"projectKey"`
+	Url          string     `json:"url"`
 }
 
 func (in *Jira) extractJiraConfig() *cxsdk.JiraWebhookInputData {
@@ -609,7 +825,11 @@ func (in *Jira) DeepEqual(jira *Jira) (bool, utils.Diff) {
 }
 
 type Opsgenie struct {
+	// Exactly one of url and urlFrom must be set.
+	// +optional
 	Url string `json:"url"`
+	// +optional
+	UrlFrom *ValueFrom `json:"urlFrom,omitempty"`
 }
 
 func (in *Opsgenie) extractOpsgenieConfig() *cxsdk.OpsgenieWebhookInputData {
@@ -641,7 +861,11 @@ func (in *Opsgenie) DeepEqual(opsgenie *Opsgenie) (bool, utils.Diff) {
 type Demisto struct {
 	Uuid    string `json:"uuid"`
 	Payload string `json:"payload"`
-	Url     string `json:"url"`
+	// Exactly one of url and urlFrom must be set.
+	// +optional
+	Url string `json:"url"`
+	// +optional
+	UrlFrom *ValueFrom `json:"urlFrom,omitempty"`
 }
 
 func (in *Demisto) extractDemistoConfig() *cxsdk.DemistoWebhookInputData {
@@ -771,10 +995,48 @@ type OutboundWebhookStatus struct {
 	Name string `json:"name"`
 
 	OutboundWebhookType *OutboundWebhookTypeStatus `json:"outboundWebhookType"`
+
+	// The outcome of the last test notification, sent when the TestWebhookAnnotation was changed.
+	// +optional
+	LastTest *OutboundWebhookTest `json:"lastTest,omitempty"`
+
+	SyncStatus `json:",inline"`
+}
+
+// TestWebhookAnnotation set on an outbound webhook sends a test notification through it, e.g. to Slack or PagerDuty,
+// each time its value changes. A timestamp makes a good value:
+// kubectl annotate outboundwebhook my-webhook app.coralogix.com/test-webhook="$(date +%s)" --overwrite
+const TestWebhookAnnotation = "app.coralogix.com/test-webhook"
+
+// OutboundWebhookTest is the outcome of a test notification sent through an outbound webhook.
+type OutboundWebhookTest struct {
+	// The value of the TestWebhookAnnotation the test was sent for.
+	Trigger string `json:"trigger"`
+
+	// The time the test was sent.
+	Time metav1.Time `json:"time"`
+
+	// Whether the notification was delivered.
+	Succeeded bool `json:"succeeded"`
+
+	// The status code returned by the destination of the webhook, if it was reached.
+	// +optional
+	StatusCode *int32 `json:"statusCode,omitempty"`
+
+	// Why the notification wasn't delivered.
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`,priority=1
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,priority=1
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:storageversion
 
 // OutboundWebhook is the Schema for the outboundwebhooks API
 type OutboundWebhook struct {
@@ -848,6 +1110,31 @@ func (in *OutboundWebhookSpec) ExtractOutgoingWebhookInputData() (*cxsdk.Outgoin
 	return in.OutboundWebhookType.appendOutgoingWebhookConfig(webhookData)
 }
 
+// OutboundWebhookReference refers to an OutboundWebhook resource.
+type OutboundWebhookReference struct {
+	// The name of the referenced outbound webhook.
+	Name string `json:"name"`
+
+	// The namespace of the referenced outbound webhook. Defaults to the namespace of the referring resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ObjectKey returns the key of the referenced outbound webhook, which is in namespace when the reference has none.
+func (in OutboundWebhookReference) ObjectKey(namespace string) types.NamespacedName {
+	if in.Namespace != "" {
+		namespace = in.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: in.Name}
+}
+
+func (in OutboundWebhookReference) String() string {
+	if in.Namespace == "" {
+		return in.Name
+	}
+	return in.Namespace + "/" + in.Name
+}
+
 //+kubebuilder:object:root=true
 
 // OutboundWebhookList contains a list of OutboundWebhook
@@ -0,0 +1,149 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func secretsWebhookType() *OutboundWebhookType {
+	return &OutboundWebhookType{
+		GenericWebhook: &GenericWebhook{
+			Url:         "https://example.com",
+			Headers:     map[string]string{"Content-Type": "application/json"},
+			HeadersFrom: map[string]ValueFrom{"Authorization": *secretValue("generic", "token")},
+		},
+		Jira: &Jira{
+			ApiTokenFrom: secretValue("jira", "token"),
+			Email:        "jira@example.com",
+			ProjectKey:   "OPS",
+		},
+	}
+}
+
+func TestOutboundWebhookTypeSecretNames(t *testing.T) {
+	names := secretsWebhookType().SecretNames()
+	if want := []string{"jira", "generic"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("SecretNames() = %v, want %v", names, want)
+	}
+
+	if names := (&OutboundWebhookType{Slack: &Slack{Url: "https://hooks.slack.com"}}).SecretNames(); len(names) != 0 {
+		t.Errorf("SecretNames() of inline values = %v, want none", names)
+	}
+}
+
+func TestOutboundWebhookTypeResolveSecrets(t *testing.T) {
+	spec := secretsWebhookType()
+	resolved, err := spec.ResolveSecrets(func(ref *corev1.SecretKeySelector) (string, error) {
+		return ref.Name + "/" + ref.Key, nil
+	})
+	if err != nil {
+		t.Fatalf("ResolveSecrets() returned %v", err)
+	}
+
+	if resolved.Jira.ApiToken != "jira/token" || resolved.Jira.ApiTokenFrom != nil {
+		t.Errorf("resolved jira = %+v", resolved.Jira)
+	}
+	wantHeaders := map[string]string{"Content-Type": "application/json", "Authorization": "generic/token"}
+	if !reflect.DeepEqual(resolved.GenericWebhook.Headers, wantHeaders) || resolved.GenericWebhook.HeadersFrom != nil {
+		t.Errorf("resolved generic webhook = %+v", resolved.GenericWebhook)
+	}
+	if spec.Jira.ApiTokenFrom == nil || len(spec.GenericWebhook.HeadersFrom) != 1 || len(spec.GenericWebhook.Headers) != 1 {
+		t.Error("ResolveSecrets() should not change the outbound webhook type")
+	}
+
+	_, err = spec.ResolveSecrets(func(*corev1.SecretKeySelector) (string, error) {
+		return "", fmt.Errorf("secret not found")
+	})
+	if err == nil {
+		t.Error("ResolveSecrets() should fail when a Secret can't be read")
+	}
+}
+
+func TestOutboundWebhookTypeReferSecret(t *testing.T) {
+	spec := &OutboundWebhookType{
+		GenericWebhook: &GenericWebhook{
+			Url:         "https://example.com",
+			Headers:     map[string]string{"Content-Type": "application/json", "X-Api-Key": "key"},
+			HeadersFrom: map[string]ValueFrom{"Authorization": *secretValue("generic", "token")},
+		},
+		Jira: &Jira{ApiToken: "token", Email: "jira@example.com"},
+	}
+	referred, keys := spec.ReferSecret("webhook-credentials")
+
+	if want := []string{"apiToken", "Content-Type", "X-Api-Key"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("ReferSecret() keys = %v, want %v", keys, want)
+	}
+	if referred.Jira.ApiToken != "" || !reflect.DeepEqual(referred.Jira.ApiTokenFrom, secretValue("webhook-credentials", "apiToken")) {
+		t.Errorf("referred jira = %+v", referred.Jira)
+	}
+	wantHeadersFrom := map[string]ValueFrom{
+		"Authorization": *secretValue("generic", "token"),
+		"Content-Type":  *secretValue("webhook-credentials", "Content-Type"),
+		"X-Api-Key":     *secretValue("webhook-credentials", "X-Api-Key"),
+	}
+	if referred.GenericWebhook.Headers != nil || !reflect.DeepEqual(referred.GenericWebhook.HeadersFrom, wantHeadersFrom) {
+		t.Errorf("referred generic webhook = %+v", referred.GenericWebhook)
+	}
+	if spec.Jira.ApiToken != "token" || len(spec.GenericWebhook.Headers) != 2 {
+		t.Error("ReferSecret() should not change the outbound webhook type")
+	}
+}
+
+func TestHashSecret(t *testing.T) {
+	if hash := HashSecret("token"); hash != HashSecret("token") || hash == HashSecret("other") || !strings.HasPrefix(hash, SecretHashPrefix) {
+		t.Errorf("HashSecret(token) = %q", hash)
+	}
+	if hash := HashSecret(""); hash != "" {
+		t.Errorf("HashSecret of an empty value = %q, want it empty", hash)
+	}
+}
+
+func TestOutboundWebhookTypeHashSecrets(t *testing.T) {
+	spec := &OutboundWebhookType{
+		GenericWebhook: &GenericWebhook{
+			Url:     "https://example.com",
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		},
+		Jira: &Jira{ApiToken: "secret", Email: "jira@example.com", ProjectKey: "OPS"},
+	}
+	hashed := spec.HashSecrets()
+	if hashed.Jira.ApiToken != HashSecret("secret") || hashed.Jira.Email != "jira@example.com" {
+		t.Errorf("hashed jira = %+v", hashed.Jira)
+	}
+	if hashed.GenericWebhook.Headers["Authorization"] != HashSecret("Bearer secret") || hashed.GenericWebhook.Url != "https://example.com" {
+		t.Errorf("hashed generic webhook = %+v", hashed.GenericWebhook)
+	}
+	if spec.Jira.ApiToken != "secret" {
+		t.Error("HashSecrets() should not change the outbound webhook type")
+	}
+}
+
+func TestOutboundWebhookTypeStatusHashSecrets(t *testing.T) {
+	status := &OutboundWebhookTypeStatus{
+		GenericWebhook: &GenericWebhookStatus{
+			Url:     "https://example.com",
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		},
+		Jira: &Jira{ApiToken: "secret", Email: "jira@example.com", ProjectKey: "OPS"},
+	}
+	status.HashSecrets()
+
+	if status.GenericWebhook.Headers["Authorization"] != HashSecret("Bearer secret") {
+		t.Errorf("hashed status headers = %v", status.GenericWebhook.Headers)
+	}
+	if status.Jira.ApiToken != HashSecret("secret") || status.Jira.Email != "jira@example.com" {
+		t.Errorf("hashed status jira = %+v", status.Jira)
+	}
+
+	spec := &OutboundWebhookSpec{Name: "jira", OutboundWebhookType: OutboundWebhookType{
+		Jira: &Jira{ApiToken: "secret", Email: "jira@example.com", ProjectKey: "OPS"},
+	}}
+	spec.OutboundWebhookType = *spec.OutboundWebhookType.HashSecrets()
+	if equal, diff := spec.DeepEqual(&OutboundWebhookStatus{Name: "jira", OutboundWebhookType: &OutboundWebhookTypeStatus{Jira: status.Jira}}); !equal {
+		t.Errorf("the hashed spec should equal the hashed status, got diff %+v", diff)
+	}
+}
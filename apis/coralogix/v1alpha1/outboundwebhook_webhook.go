@@ -0,0 +1,109 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-coralogix-com-v1alpha1-outboundwebhook,mutating=false,failurePolicy=fail,sideEffects=None,groups=coralogix.com,resources=outboundwebhooks,verbs=create;update,versions=v1alpha1,name=voutboundwebhook.coralogix.com,admissionReviewVersions=v1
+
+// OutboundWebhookValidator rejects invalid outbound webhooks when they are applied, instead of failing to reconcile them later.
+// +kubebuilder:object:generate=false
+type OutboundWebhookValidator struct{}
+
+var _ admission.CustomValidator = &OutboundWebhookValidator{}
+
+// SetupWebhookWithManager registers the validating webhook of outbound webhooks in the webhook server of mgr.
+func (v *OutboundWebhookValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&OutboundWebhook{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *OutboundWebhookValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	webhook, ok := obj.(*OutboundWebhook)
+	if !ok {
+		return nil, fmt.Errorf("expected an OutboundWebhook, got %T", obj)
+	}
+	return nil, invalid("OutboundWebhook", webhook.Name, webhook.Spec.Validate(field.NewPath("spec")))
+}
+
+func (v *OutboundWebhookValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldWebhook, ok := oldObj.(*OutboundWebhook)
+	if !ok {
+		return nil, fmt.Errorf("expected an OutboundWebhook, got %T", oldObj)
+	}
+	webhook, ok := newObj.(*OutboundWebhook)
+	if !ok {
+		return nil, fmt.Errorf("expected an OutboundWebhook, got %T", newObj)
+	}
+
+	if skipsValidation(webhook, oldWebhook.Spec, webhook.Spec) {
+		return nil, nil
+	}
+	return nil, invalid("OutboundWebhook", webhook.Name, webhook.Spec.Validate(field.NewPath("spec")))
+}
+
+func (v *OutboundWebhookValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// Validate returns the errors of the spec that are not covered by the schema of the CRD, with their field paths.
+func (in *OutboundWebhookSpec) Validate(path *field.Path) field.ErrorList {
+	webhookType := in.OutboundWebhookType
+	if err := validateExactlyOne(path.Child("outboundWebhookType"), map[string]bool{
+		"genericWebhook": webhookType.GenericWebhook != nil,
+		"slack":          webhookType.Slack != nil,
+		"pagerDuty":      webhookType.PagerDuty != nil,
+		"sendLog":        webhookType.SendLog != nil,
+		"emailGroup":     webhookType.EmailGroup != nil,
+		"microsoftTeams": webhookType.MicrosoftTeams != nil,
+		"jira":           webhookType.Jira != nil,
+		"opsgenie":       webhookType.Opsgenie != nil,
+		"demisto":        webhookType.Demisto != nil,
+		"awsEventBridge": webhookType.AwsEventBridge != nil,
+	}); err != nil {
+		return field.ErrorList{err}
+	}
+
+	var errs field.ErrorList
+	typePath := path.Child("outboundWebhookType")
+	for _, f := range webhookType.secretFields() {
+		if err := validateExactlyOne(typePath.Child(f.parent), map[string]bool{
+			f.name:       *f.value != "",
+			f.fromName(): *f.from != nil,
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if genericWebhook := webhookType.GenericWebhook; genericWebhook != nil {
+		for name := range genericWebhook.HeadersFrom {
+			if _, ok := genericWebhook.Headers[name]; ok {
+				errs = append(errs, field.Duplicate(typePath.Child("genericWebhook", "headersFrom").Key(name), name))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestOutboundWebhookSpecValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		webhookType OutboundWebhookType
+		wantFields  []string
+	}{
+		{
+			name:        "valid",
+			webhookType: OutboundWebhookType{Slack: &Slack{Url: "https://hooks.slack.com/services/T0/B0/X"}},
+		},
+		{
+			name:        "url from a secret",
+			webhookType: OutboundWebhookType{Slack: &Slack{UrlFrom: secretValue("slack", "url")}},
+		},
+		{
+			name:        "both url and url from a secret",
+			webhookType: OutboundWebhookType{Slack: &Slack{Url: "https://hooks.slack.com/services/T0/B0/X", UrlFrom: secretValue("slack", "url")}},
+			wantFields:  []string{"spec.outboundWebhookType.slack"},
+		},
+		{
+			name:        "no api token",
+			webhookType: OutboundWebhookType{Jira: &Jira{Email: "ops@example.com"}},
+			wantFields:  []string{"spec.outboundWebhookType.jira"},
+		},
+		{
+			name: "header both inline and from a secret",
+			webhookType: OutboundWebhookType{GenericWebhook: &GenericWebhook{
+				Headers:     map[string]string{"Authorization": "Bearer token", "Accept": "application/json"},
+				HeadersFrom: map[string]ValueFrom{"Authorization": *secretValue("webhook", "token")},
+			}},
+			wantFields: []string{"spec.outboundWebhookType.genericWebhook.headersFrom[Authorization]"},
+		},
+		{
+			name:        "no type",
+			webhookType: OutboundWebhookType{},
+			wantFields:  []string{"spec.outboundWebhookType"},
+		},
+		{
+			name:        "several types",
+			webhookType: OutboundWebhookType{Slack: &Slack{}, Jira: &Jira{}},
+			wantFields:  []string{"spec.outboundWebhookType"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OutboundWebhookSpec{Name: "webhook", OutboundWebhookType: tt.webhookType}
+			assertErrorFields(t, spec.Validate(field.NewPath("spec")), tt.wantFields)
+		})
+	}
+}
+
+func secretValue(name, key string) *ValueFrom {
+	return &ValueFrom{SecretKeyRef: &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	}}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PlanAction is a change to a remote object.
+// +kubebuilder:validation:Enum=Create;Update;Delete
+type PlanAction string
+
+const (
+	PlanActionCreate PlanAction = "Create"
+	PlanActionUpdate PlanAction = "Update"
+	PlanActionDelete PlanAction = "Delete"
+)
+
+// Plan is a change the operator would make to the remote object, recorded instead of made when it runs in dry-run mode.
+type Plan struct {
+	// The change to the remote object.
+	Action PlanAction `json:"action"`
+
+	// The path of the first field of the remote object that differs from the spec, for updates.
+	// +optional
+	Field string `json:"field,omitempty"`
+
+	// The request that would be sent to Coralogix, as JSON.
+	// +optional
+	Request string `json:"request,omitempty"`
+
+	// The time the change was planned.
+	PlannedAt metav1.Time `json:"plannedAt"`
+}
+
+// NewPlan returns the Plan of action, planned now.
+func NewPlan(action PlanAction, field, request string) *Plan {
+	return &Plan{
+		Action:    action,
+		Field:     field,
+		Request:   request,
+		PlannedAt: metav1.Now(),
+	}
+}
@@ -31,9 +31,25 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // RecordingRuleGroupSetSpec defines the desired state of RecordingRuleGroupSet
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef)",message="accountRef can't be added or removed"
 type RecordingRuleGroupSetSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	Groups []RecordingRuleGroup `json:"groups"`
+
+	// The Coralogix account the resource is managed in. Defaults to the account the operator was started with.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+
+	// What to do when the remote recording rule group set is changed outside of the operator, e.g. in the Coralogix UI.
+	// Enforce overwrites the remote recording rule group set with the spec, and Report only records the difference in status.lastDrift.
+	// +optional
+	// +kubebuilder:default=Enforce
+	DriftPolicy *DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// What to do with the remote recording rule group set when the resource is deleted. Delete deletes it, and Retain leaves it in
+	// Coralogix, e.g. to move the resource to another namespace. Defaults to the operator's deletion policy.
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 func (in *RecordingRuleGroupSetSpec) DeepEqual(status RecordingRuleGroupSetStatus) (bool, utils.Diff) {
@@ -210,10 +226,18 @@ type RecordingRuleGroupSetStatus struct {
 	ID *string `json:"id"`
 
 	Groups []RecordingRuleGroup `json:"groups,omitempty"`
+
+	SyncStatus `json:",inline"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`,priority=1
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,priority=1
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:storageversion
 
 // RecordingRuleGroupSet is the Schema for the recordingrulegroupsets API
@@ -0,0 +1,91 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-coralogix-com-v1alpha1-recordingrulegroupset,mutating=false,failurePolicy=fail,sideEffects=None,groups=coralogix.com,resources=recordingrulegroupsets,verbs=create;update,versions=v1alpha1,name=vrecordingrulegroupset.coralogix.com,admissionReviewVersions=v1
+
+// RecordingRuleGroupSetValidator rejects invalid recording rule group sets when they are applied,
+// instead of failing to reconcile them later.
+// +kubebuilder:object:generate=false
+type RecordingRuleGroupSetValidator struct{}
+
+var _ admission.CustomValidator = &RecordingRuleGroupSetValidator{}
+
+// SetupWebhookWithManager registers the validating webhook of recording rule group sets in the webhook server of mgr.
+func (v *RecordingRuleGroupSetValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&RecordingRuleGroupSet{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *RecordingRuleGroupSetValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	set, ok := obj.(*RecordingRuleGroupSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a RecordingRuleGroupSet, got %T", obj)
+	}
+	return nil, invalid("RecordingRuleGroupSet", set.Name, set.Spec.Validate(field.NewPath("spec")))
+}
+
+func (v *RecordingRuleGroupSetValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldSet, ok := oldObj.(*RecordingRuleGroupSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a RecordingRuleGroupSet, got %T", oldObj)
+	}
+	set, ok := newObj.(*RecordingRuleGroupSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a RecordingRuleGroupSet, got %T", newObj)
+	}
+
+	if skipsValidation(set, oldSet.Spec, set.Spec) {
+		return nil, nil
+	}
+	return nil, invalid("RecordingRuleGroupSet", set.Name, set.Spec.Validate(field.NewPath("spec")))
+}
+
+func (v *RecordingRuleGroupSetValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// Validate returns the errors of the spec that are not covered by the schema of the CRD, with their field paths.
+func (in *RecordingRuleGroupSetSpec) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, group := range in.Groups {
+		for j, rule := range group.Rules {
+			rulePath := path.Child("groups").Index(i).Child("rules").Index(j)
+			if !model.IsValidMetricName(model.LabelValue(rule.Record)) {
+				errs = append(errs, field.Invalid(rulePath.Child("record"), rule.Record, "must be a valid metric name"))
+			}
+			if _, err := parser.ParseExpr(rule.Expr); err != nil {
+				errs = append(errs, field.Invalid(rulePath.Child("expr"), rule.Expr, err.Error()))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,35 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestRecordingRuleGroupSetSpecValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		rule       RecordingRule
+		wantFields []string
+	}{
+		{
+			name: "valid",
+			rule: RecordingRule{Record: "job:http_requests:rate5m", Expr: `sum by (job) (rate(http_requests_total[5m]))`},
+		},
+		{
+			name:       "invalid expression",
+			rule:       RecordingRule{Record: "job:http_requests:rate5m", Expr: `sum by (job) (rate(http_requests_total[5m])`},
+			wantFields: []string{"spec.groups[0].rules[0].expr"},
+		},
+		{
+			name:       "invalid record",
+			rule:       RecordingRule{Record: "http-requests", Expr: `http_requests_total`},
+			wantFields: []string{"spec.groups[0].rules[0].record"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := RecordingRuleGroupSetSpec{Groups: []RecordingRuleGroup{{Name: "group", Rules: []RecordingRule{tt.rule}}}}
+			assertErrorFields(t, spec.Validate(field.NewPath("spec")), tt.wantFields)
+		})
+	}
+}
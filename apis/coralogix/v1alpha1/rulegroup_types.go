@@ -660,6 +660,7 @@ func (in *RuleSubGroup) DeepEqual(actualSubgroup RuleSubGroup) (bool, utils.Diff
 }
 
 // RuleGroupSpec defines the Desired state of RuleGroup
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef)",message="accountRef can't be added or removed"
 type RuleGroupSpec struct {
 	//+kubebuilder:validation:MinLength=0
 	Name string `json:"name"`
@@ -691,6 +692,21 @@ type RuleGroupSpec struct {
 
 	// +optional
 	RuleSubgroups []RuleSubGroup `json:"subgroups,omitempty"`
+
+	// The Coralogix account the resource is managed in. Defaults to the account the operator was started with.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+
+	// What to do when the remote rule group is changed outside of the operator, e.g. in the Coralogix UI.
+	// Enforce overwrites the remote rule group with the spec, and Report only records the difference in status.lastDrift.
+	// +optional
+	// +kubebuilder:default=Enforce
+	DriftPolicy *DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// What to do with the remote rule group when the resource is deleted. Delete deletes it, and Retain leaves it in
+	// Coralogix, e.g. to move the resource to another namespace. Defaults to the operator's deletion policy.
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Debug;Verbose;Info;Warning;Error;Critical
@@ -1071,10 +1087,18 @@ type RuleGroupStatus struct {
 	Order *int32 `json:"order,omitempty"`
 
 	RuleSubgroups []RuleSubGroup `json:"subgroups,omitempty"`
+
+	SyncStatus `json:",inline"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`,priority=1
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,priority=1
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:storageversion
 
 // RuleGroup is the Schema for the rulegroups API
@@ -0,0 +1,123 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-coralogix-com-v1alpha1-rulegroup,mutating=false,failurePolicy=fail,sideEffects=None,groups=coralogix.com,resources=rulegroups,verbs=create;update,versions=v1alpha1,name=vrulegroup.coralogix.com,admissionReviewVersions=v1
+
+// RuleGroupValidator rejects invalid rule groups when they are applied, instead of failing to reconcile them later.
+// +kubebuilder:object:generate=false
+type RuleGroupValidator struct{}
+
+var _ admission.CustomValidator = &RuleGroupValidator{}
+
+// SetupWebhookWithManager registers the validating webhook of rule groups in the webhook server of mgr.
+func (v *RuleGroupValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&RuleGroup{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *RuleGroupValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ruleGroup, ok := obj.(*RuleGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a RuleGroup, got %T", obj)
+	}
+	return nil, invalid("RuleGroup", ruleGroup.Name, ruleGroup.Spec.Validate(field.NewPath("spec")))
+}
+
+func (v *RuleGroupValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldRuleGroup, ok := oldObj.(*RuleGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a RuleGroup, got %T", oldObj)
+	}
+	ruleGroup, ok := newObj.(*RuleGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a RuleGroup, got %T", newObj)
+	}
+
+	if skipsValidation(ruleGroup, oldRuleGroup.Spec, ruleGroup.Spec) {
+		return nil, nil
+	}
+	return nil, invalid("RuleGroup", ruleGroup.Name, ruleGroup.Spec.Validate(field.NewPath("spec")))
+}
+
+func (v *RuleGroupValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// Validate returns the errors of the spec that are not covered by the schema of the CRD, with their field paths.
+func (in *RuleGroupSpec) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	subgroupOrders := make(map[int32]bool)
+	for i, subgroup := range in.RuleSubgroups {
+		subgroupPath := path.Child("subgroups").Index(i)
+		if subgroup.Order != nil {
+			if subgroupOrders[*subgroup.Order] {
+				errs = append(errs, field.Duplicate(subgroupPath.Child("order"), *subgroup.Order))
+			}
+			subgroupOrders[*subgroup.Order] = true
+		}
+
+		for j, rule := range subgroup.Rules {
+			errs = append(errs, rule.validate(subgroupPath.Child("rules").Index(j))...)
+		}
+	}
+	return errs
+}
+
+func (in *Rule) validate(path *field.Path) field.ErrorList {
+	if err := validateExactlyOne(path, map[string]bool{
+		"parse":            in.Parse != nil,
+		"block":            in.Block != nil,
+		"jsonExtract":      in.JsonExtract != nil,
+		"replace":          in.Replace != nil,
+		"extractTimestamp": in.ExtractTimestamp != nil,
+		"removeFields":     in.RemoveFields != nil,
+		"jsonStringify":    in.JsonStringify != nil,
+		"extract":          in.Extract != nil,
+		"parseJsonField":   in.ParseJsonField != nil,
+	}); err != nil {
+		return field.ErrorList{err}
+	}
+
+	var err *field.Error
+	switch {
+	case in.Parse != nil:
+		err = validateRegex(path.Child("parse", "regex"), in.Parse.Regex)
+	case in.Block != nil:
+		err = validateRegex(path.Child("block", "regex"), in.Block.Regex)
+	case in.Replace != nil:
+		err = validateRegex(path.Child("replace", "regex"), in.Replace.Regex)
+	case in.Extract != nil:
+		err = validateRegex(path.Child("extract", "regex"), in.Extract.Regex)
+	}
+	if err != nil {
+		return field.ErrorList{err}
+	}
+	return nil
+}
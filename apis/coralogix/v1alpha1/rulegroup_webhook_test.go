@@ -0,0 +1,70 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestRuleGroupSpecValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		subgroups  []RuleSubGroup
+		wantFields []string
+	}{
+		{
+			name: "valid",
+			subgroups: []RuleSubGroup{
+				{Order: ptr.To[int32](1), Rules: []Rule{{Name: "parse", Parse: &Parse{Regex: `(?P<severity>\w+)`}}}},
+				{Order: ptr.To[int32](2), Rules: []Rule{{Name: "block", Block: &Block{Regex: `^healthz$`}}}},
+			},
+		},
+		{
+			name: "invalid regex",
+			subgroups: []RuleSubGroup{
+				{Rules: []Rule{{Name: "replace", Replace: &Replace{Regex: `(?=lookahead)`}}}},
+			},
+			wantFields: []string{"spec.subgroups[0].rules[0].replace.regex"},
+		},
+		{
+			name: "several rule types",
+			subgroups: []RuleSubGroup{
+				{Rules: []Rule{{Name: "both", Parse: &Parse{Regex: `.*`}, Extract: &Extract{Regex: `.*`}}}},
+			},
+			wantFields: []string{"spec.subgroups[0].rules[0]"},
+		},
+		{
+			name: "no rule type",
+			subgroups: []RuleSubGroup{
+				{Rules: []Rule{{Name: "none"}}},
+			},
+			wantFields: []string{"spec.subgroups[0].rules[0]"},
+		},
+		{
+			name: "duplicate order",
+			subgroups: []RuleSubGroup{
+				{Order: ptr.To[int32](1), Rules: []Rule{{Name: "first", RemoveFields: &RemoveFields{}}}},
+				{Order: ptr.To[int32](1), Rules: []Rule{{Name: "second", RemoveFields: &RemoveFields{}}}},
+			},
+			wantFields: []string{"spec.subgroups[1].order"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := RuleGroupSpec{Name: "rule-group", RuleSubgroups: tt.subgroups}
+			assertErrorFields(t, spec.Validate(field.NewPath("spec")), tt.wantFields)
+		})
+	}
+}
+
+func assertErrorFields(t *testing.T, errs field.ErrorList, wantFields []string) {
+	t.Helper()
+	if len(errs) != len(wantFields) {
+		t.Fatalf("expected errors on %v, got %v", wantFields, errs)
+	}
+	for i, err := range errs {
+		if err.Field != wantFields[i] {
+			t.Errorf("expected an error on %s, got %v", wantFields[i], err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SecretHashPrefix prefixes the hashes shown in place of sensitive values.
+const SecretHashPrefix = "sha256:"
+
+// HashSecret returns the hash shown in place of a sensitive value in the status of resources and in the operator logs.
+// Equal values have equal hashes, so the status can still be compared with the spec. Empty values are kept as they are.
+func HashSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return SecretHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// ValueFrom is the source of a sensitive value, given instead of the value itself.
+type ValueFrom struct {
+	// The key of a Secret in the namespace of the resource holding the value.
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// secretField is a sensitive field, which either holds its value or takes it from a Secret.
+// +kubebuilder:object:generate=false
+type secretField struct {
+	// The json names of the struct holding the field and of the field, e.g. jira and apiToken.
+	parent, name string
+	value        *string
+	from         **ValueFrom
+}
+
+// fromName is the json name of the field taking the value from a Secret, e.g. apiTokenFrom.
+func (f secretField) fromName() string {
+	return f.name + "From"
+}
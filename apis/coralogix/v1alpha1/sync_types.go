@@ -0,0 +1,119 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionTypeReady is True when the remote object exists and the last reconciliation succeeded.
+	ConditionTypeReady = "Ready"
+	// ConditionTypeSynced is True when the remote object matches the spec.
+	ConditionTypeSynced = "Synced"
+	// ConditionTypePaused is True while the reconciliation of the resource is paused with ReconcileAnnotation.
+	ConditionTypePaused = "Paused"
+)
+
+// ImportIDAnnotation is the ID of an existing remote object that a resource without a remote object yet binds to,
+// instead of creating a new one. The remote object is then reconciled to the spec like any other.
+const ImportIDAnnotation = "app.coralogix.com/import-id"
+
+// ReconcileAnnotation set to ReconcilePaused on a resource, or as a label of its namespace, stops the operator from
+// changing or deleting its remote object until it is removed, e.g. while the object is debugged in the Coralogix UI.
+const (
+	ReconcileAnnotation = "app.coralogix.com/reconcile"
+	ReconcilePaused     = "paused"
+)
+
+const (
+	ReasonSynced          = "Synced"
+	ReasonDriftReported   = "DriftReported"
+	ReasonReconcileError  = "ReconcileError"
+	ReasonPaused          = "Paused"
+	ReasonResumed         = "Resumed"
+	ReasonPlanned         = "Planned"
+	ReasonWebhookNotReady = "WebhookNotReady"
+	ReasonImportConflict  = "ImportConflict"
+)
+
+// ImportConflictError is returned when a resource imports a remote object that another resource imports as well,
+// since both would overwrite the remote object with their own spec.
+// +kubebuilder:object:generate=false
+type ImportConflictError struct {
+	ID       string
+	Resource string
+}
+
+func (e *ImportConflictError) Error() string {
+	return fmt.Sprintf("remote object %s is imported by %s as well", e.ID, e.Resource)
+}
+
+// SyncStatus is the outcome of the last reconciliation of a resource against its remote object.
+type SyncStatus struct {
+	// The Ready and Synced conditions of the resource. When the last reconciliation failed,
+	// their reason and message describe the error returned by Coralogix.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The generation of the spec the conditions refer to.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The last time the remote object was successfully synced with the spec.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// The last difference found between the spec and the remote object.
+	// Depending on the drift policy, the remote object was overwritten by the spec or left as is.
+	// +optional
+	LastDrift *Drift `json:"lastDrift,omitempty"`
+
+	// The change the operator would have made to the remote object in its last reconciliation, had it not run
+	// in dry-run mode. Empty when the remote object matches the spec, or when the operator doesn't run in dry-run mode.
+	// +optional
+	Plan *Plan `json:"plan,omitempty"`
+
+	// The ID in the import-id annotation the resource was bound to. Once imported, a remote object that is deleted
+	// is recreated rather than imported again.
+	// +optional
+	ImportedID string `json:"importedID,omitempty"`
+}
+
+// GetSyncStatus returns the sync status of the alert.
+func (in *Alert) GetSyncStatus() *SyncStatus {
+	return &in.Status.SyncStatus
+}
+
+// GetSyncStatus returns the sync status of the rule group.
+func (in *RuleGroup) GetSyncStatus() *SyncStatus {
+	return &in.Status.SyncStatus
+}
+
+// GetSyncStatus returns the sync status of the recording rule group set.
+func (in *RecordingRuleGroupSet) GetSyncStatus() *SyncStatus {
+	return &in.Status.SyncStatus
+}
+
+// GetSyncStatus returns the sync status of the outbound webhook.
+func (in *OutboundWebhook) GetSyncStatus() *SyncStatus {
+	return &in.Status.SyncStatus
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
+)
+
+// ClientSetResolver resolves the clientset of the Coralogix account a resource refers to.
+// +kubebuilder:object:generate=false
+type ClientSetResolver interface {
+	ClientSet(ctx context.Context, namespace string, ref *AccountReference) (clientset.ClientSetInterface, error)
+}
+
+// skipsValidation reports whether an update leaves the spec as is, e.g. removing the finalizer of a deleted resource.
+// Such updates must not be blocked by rules that were added after the resource was created.
+func skipsValidation(obj metav1.Object, oldSpec, newSpec any) bool {
+	return !obj.GetDeletionTimestamp().IsZero() || equality.Semantic.DeepEqual(oldSpec, newSpec)
+}
+
+// invalid returns the error rejecting the object of kind with errs, or nil if there are none.
+func invalid(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
+
+// validateExactlyOne returns an error unless exactly one of members, by their json names, is set.
+func validateExactlyOne(path *field.Path, members map[string]bool) *field.Error {
+	var names, set []string
+	for name, isSet := range members {
+		names = append(names, name)
+		if isSet {
+			set = append(set, name)
+		}
+	}
+	if len(set) == 1 {
+		return nil
+	}
+
+	sort.Strings(names)
+	sort.Strings(set)
+	return field.Invalid(path, set, "exactly one of "+strings.Join(names, ", ")+" must be set")
+}
+
+// validateRegex returns an error if regex doesn't compile with the RE2 syntax used by Coralogix.
+func validateRegex(path *field.Path, regex string) *field.Error {
+	if _, err := regexp.Compile(regex); err != nil {
+		return field.Invalid(path, regex, err.Error())
+	}
+	return nil
+}
@@ -21,9 +21,46 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountReference) DeepCopyInto(out *AccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountReference.
+func (in *AccountReference) DeepCopy() *AccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountSecretKeySelector) DeepCopyInto(out *AccountSecretKeySelector) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountSecretKeySelector.
+func (in *AccountSecretKeySelector) DeepCopy() *AccountSecretKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountSecretKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Alert) DeepCopyInto(out *Alert) {
 	*out = *in
@@ -121,6 +158,21 @@ func (in *AlertSpec) DeepCopyInto(out *AlertSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.AlertType.DeepCopyInto(&out.AlertType)
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(DriftPolicy)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSpec.
@@ -176,6 +228,7 @@ func (in *AlertStatus) DeepCopyInto(out *AlertStatus) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.AlertType.DeepCopyInto(&out.AlertType)
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertStatus.
@@ -273,9 +326,173 @@ func (in *Block) DeepCopy() *Block {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCoralogixAccount) DeepCopyInto(out *ClusterCoralogixAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCoralogixAccount.
+func (in *ClusterCoralogixAccount) DeepCopy() *ClusterCoralogixAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCoralogixAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCoralogixAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCoralogixAccountList) DeepCopyInto(out *ClusterCoralogixAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterCoralogixAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCoralogixAccountList.
+func (in *ClusterCoralogixAccountList) DeepCopy() *ClusterCoralogixAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCoralogixAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCoralogixAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoralogixAccount) DeepCopyInto(out *CoralogixAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoralogixAccount.
+func (in *CoralogixAccount) DeepCopy() *CoralogixAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(CoralogixAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CoralogixAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoralogixAccountList) DeepCopyInto(out *CoralogixAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CoralogixAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoralogixAccountList.
+func (in *CoralogixAccountList) DeepCopy() *CoralogixAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(CoralogixAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CoralogixAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoralogixAccountSpec) DeepCopyInto(out *CoralogixAccountSpec) {
+	*out = *in
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(string)
+		**out = **in
+	}
+	in.APIKeySecretRef.DeepCopyInto(&out.APIKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoralogixAccountSpec.
+func (in *CoralogixAccountSpec) DeepCopy() *CoralogixAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CoralogixAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoralogixAccountStatus) DeepCopyInto(out *CoralogixAccountStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoralogixAccountStatus.
+func (in *CoralogixAccountStatus) DeepCopy() *CoralogixAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CoralogixAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Demisto) DeepCopyInto(out *Demisto) {
 	*out = *in
+	if in.UrlFrom != nil {
+		in, out := &in.UrlFrom, &out.UrlFrom
+		*out = new(ValueFrom)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Demisto.
@@ -288,6 +505,22 @@ func (in *Demisto) DeepCopy() *Demisto {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Drift) DeepCopyInto(out *Drift) {
+	*out = *in
+	in.DetectedAt.DeepCopyInto(&out.DetectedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Drift.
+func (in *Drift) DeepCopy() *Drift {
+	if in == nil {
+		return nil
+	}
+	out := new(Drift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmailGroup) DeepCopyInto(out *EmailGroup) {
 	*out = *in
@@ -508,6 +741,13 @@ func (in *GenericWebhook) DeepCopyInto(out *GenericWebhook) {
 			(*out)[key] = val
 		}
 	}
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make(map[string]ValueFrom, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Payload != nil {
 		in, out := &in.Payload, &out.Payload
 		*out = new(string)
@@ -590,6 +830,11 @@ func (in *InnerFlowAlerts) DeepCopy() *InnerFlowAlerts {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Jira) DeepCopyInto(out *Jira) {
 	*out = *in
+	if in.ApiTokenFrom != nil {
+		in, out := &in.ApiTokenFrom, &out.ApiTokenFrom
+		*out = new(ValueFrom)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Jira.
@@ -732,6 +977,11 @@ func (in *Metric) DeepCopy() *Metric {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MicrosoftTeams) DeepCopyInto(out *MicrosoftTeams) {
 	*out = *in
+	if in.UrlFrom != nil {
+		in, out := &in.UrlFrom, &out.UrlFrom
+		*out = new(ValueFrom)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MicrosoftTeams.
@@ -788,6 +1038,11 @@ func (in *Notification) DeepCopyInto(out *Notification) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.IntegrationRef != nil {
+		in, out := &in.IntegrationRef, &out.IntegrationRef
+		*out = new(OutboundWebhookReference)
+		**out = **in
+	}
 	if in.EmailRecipients != nil {
 		in, out := &in.EmailRecipients, &out.EmailRecipients
 		*out = make([]string, len(*in))
@@ -835,6 +1090,11 @@ func (in *NotificationGroup) DeepCopy() *NotificationGroup {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Opsgenie) DeepCopyInto(out *Opsgenie) {
 	*out = *in
+	if in.UrlFrom != nil {
+		in, out := &in.UrlFrom, &out.UrlFrom
+		*out = new(ValueFrom)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Opsgenie.
@@ -906,10 +1166,40 @@ func (in *OutboundWebhookList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutboundWebhookReference) DeepCopyInto(out *OutboundWebhookReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundWebhookReference.
+func (in *OutboundWebhookReference) DeepCopy() *OutboundWebhookReference {
+	if in == nil {
+		return nil
+	}
+	out := new(OutboundWebhookReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutboundWebhookSpec) DeepCopyInto(out *OutboundWebhookSpec) {
 	*out = *in
 	in.OutboundWebhookType.DeepCopyInto(&out.OutboundWebhookType)
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(DriftPolicy)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundWebhookSpec.
@@ -940,6 +1230,12 @@ func (in *OutboundWebhookStatus) DeepCopyInto(out *OutboundWebhookStatus) {
 		*out = new(OutboundWebhookTypeStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LastTest != nil {
+		in, out := &in.LastTest, &out.LastTest
+		*out = new(OutboundWebhookTest)
+		(*in).DeepCopyInto(*out)
+	}
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundWebhookStatus.
@@ -952,6 +1248,27 @@ func (in *OutboundWebhookStatus) DeepCopy() *OutboundWebhookStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutboundWebhookTest) DeepCopyInto(out *OutboundWebhookTest) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundWebhookTest.
+func (in *OutboundWebhookTest) DeepCopy() *OutboundWebhookTest {
+	if in == nil {
+		return nil
+	}
+	out := new(OutboundWebhookTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutboundWebhookType) DeepCopyInto(out *OutboundWebhookType) {
 	*out = *in
@@ -968,7 +1285,7 @@ func (in *OutboundWebhookType) DeepCopyInto(out *OutboundWebhookType) {
 	if in.PagerDuty != nil {
 		in, out := &in.PagerDuty, &out.PagerDuty
 		*out = new(PagerDuty)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.SendLog != nil {
 		in, out := &in.SendLog, &out.SendLog
@@ -983,22 +1300,22 @@ func (in *OutboundWebhookType) DeepCopyInto(out *OutboundWebhookType) {
 	if in.MicrosoftTeams != nil {
 		in, out := &in.MicrosoftTeams, &out.MicrosoftTeams
 		*out = new(MicrosoftTeams)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Jira != nil {
 		in, out := &in.Jira, &out.Jira
 		*out = new(Jira)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Opsgenie != nil {
 		in, out := &in.Opsgenie, &out.Opsgenie
 		*out = new(Opsgenie)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Demisto != nil {
 		in, out := &in.Demisto, &out.Demisto
 		*out = new(Demisto)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.AwsEventBridge != nil {
 		in, out := &in.AwsEventBridge, &out.AwsEventBridge
@@ -1033,7 +1350,7 @@ func (in *OutboundWebhookTypeStatus) DeepCopyInto(out *OutboundWebhookTypeStatus
 	if in.PagerDuty != nil {
 		in, out := &in.PagerDuty, &out.PagerDuty
 		*out = new(PagerDuty)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.SendLog != nil {
 		in, out := &in.SendLog, &out.SendLog
@@ -1048,22 +1365,22 @@ func (in *OutboundWebhookTypeStatus) DeepCopyInto(out *OutboundWebhookTypeStatus
 	if in.MicrosoftTeams != nil {
 		in, out := &in.MicrosoftTeams, &out.MicrosoftTeams
 		*out = new(MicrosoftTeams)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Jira != nil {
 		in, out := &in.Jira, &out.Jira
 		*out = new(Jira)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Opsgenie != nil {
 		in, out := &in.Opsgenie, &out.Opsgenie
 		*out = new(Opsgenie)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Demisto != nil {
 		in, out := &in.Demisto, &out.Demisto
 		*out = new(Demisto)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.AwsEventBridge != nil {
 		in, out := &in.AwsEventBridge, &out.AwsEventBridge
@@ -1085,6 +1402,11 @@ func (in *OutboundWebhookTypeStatus) DeepCopy() *OutboundWebhookTypeStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PagerDuty) DeepCopyInto(out *PagerDuty) {
 	*out = *in
+	if in.ServiceKeyFrom != nil {
+		in, out := &in.ServiceKeyFrom, &out.ServiceKeyFrom
+		*out = new(ValueFrom)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDuty.
@@ -1127,6 +1449,22 @@ func (in *ParseJsonField) DeepCopy() *ParseJsonField {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Plan) DeepCopyInto(out *Plan) {
+	*out = *in
+	in.PlannedAt.DeepCopyInto(&out.PlannedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plan.
+func (in *Plan) DeepCopy() *Plan {
+	if in == nil {
+		return nil
+	}
+	out := new(Plan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Promql) DeepCopyInto(out *Promql) {
 	*out = *in
@@ -1411,6 +1749,21 @@ func (in *RecordingRuleGroupSetSpec) DeepCopyInto(out *RecordingRuleGroupSetSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(DriftPolicy)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleGroupSetSpec.
@@ -1438,6 +1791,7 @@ func (in *RecordingRuleGroupSetStatus) DeepCopyInto(out *RecordingRuleGroupSetSt
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleGroupSetStatus.
@@ -1634,6 +1988,21 @@ func (in *RuleGroupSpec) DeepCopyInto(out *RuleGroupSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(DriftPolicy)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupSpec.
@@ -1681,6 +2050,7 @@ func (in *RuleGroupStatus) DeepCopyInto(out *RuleGroupStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupStatus.
@@ -1813,6 +2183,11 @@ func (in *Slack) DeepCopyInto(out *Slack) {
 		*out = make([]SlackConfigAttachment, len(*in))
 		copy(*out, *in)
 	}
+	if in.UrlFrom != nil {
+		in, out := &in.UrlFrom, &out.UrlFrom
+		*out = new(ValueFrom)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Slack.
@@ -1911,6 +2286,42 @@ func (in *StandardConditions) DeepCopy() *StandardConditions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDrift != nil {
+		in, out := &in.LastDrift, &out.LastDrift
+		*out = new(Drift)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(Plan)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncStatus.
+func (in *SyncStatus) DeepCopy() *SyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TagFilter) DeepCopyInto(out *TagFilter) {
 	*out = *in
@@ -2108,3 +2519,23 @@ func (in *UniqueCountConditions) DeepCopy() *UniqueCountConditions {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValueFrom) DeepCopyInto(out *ValueFrom) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValueFrom.
+func (in *ValueFrom) DeepCopy() *ValueFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(ValueFrom)
+	in.DeepCopyInto(out)
+	return out
+}
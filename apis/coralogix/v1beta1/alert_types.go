@@ -0,0 +1,150 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+// AlertSpec defines the desired state of Alert
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef)",message="accountRef can't be added or removed"
+type AlertSpec struct {
+	//+kubebuilder:validation:MinLength=0
+	Name string `json:"name"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	//+kubebuilder:default=true
+	Active bool `json:"active,omitempty"`
+
+	Severity v1alpha1.AlertSeverity `json:"severity"`
+
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +optional
+	ExpirationDate *v1alpha1.ExpirationDate `json:"expirationDate,omitempty"`
+
+	// +optional
+	NotificationGroups []v1alpha1.NotificationGroup `json:"notificationGroups,omitempty"`
+
+	// +optional
+	ShowInInsight *v1alpha1.ShowInInsight `json:"showInInsight,omitempty"`
+
+	// +optional
+	PayloadFilters []string `json:"payloadFilters,omitempty"`
+
+	// +optional
+	Scheduling *v1alpha1.Scheduling `json:"scheduling,omitempty"`
+
+	AlertType v1alpha1.AlertType `json:"alertType"`
+
+	// The Coralogix account the resource is managed in. Defaults to the account the operator was started with.
+	// +optional
+	AccountRef *v1alpha1.AccountReference `json:"accountRef,omitempty"`
+
+	// What to do when the remote alert is changed outside of the operator, e.g. in the Coralogix UI.
+	// Enforce overwrites the remote alert with the spec, and Report only records the difference in status.lastDrift.
+	// +optional
+	// +kubebuilder:default=Enforce
+	DriftPolicy *v1alpha1.DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// What to do with the remote alert when the resource is deleted. Delete deletes it, and Retain leaves it in
+	// Coralogix, e.g. to move the resource to another namespace. Defaults to the operator's deletion policy.
+	// +optional
+	DeletionPolicy *v1alpha1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// AlertStatus defines the observed state of Alert
+type AlertStatus struct {
+	Status `json:",inline"`
+
+	// The remote alert as of the last reconciliation. The spec is compared with it to tell the changes made outside
+	// of the operator from the changes of the spec.
+	// +optional
+	Remote *RemoteAlert `json:"remote,omitempty"`
+}
+
+// RemoteAlert is the copy of a remote alert kept in the status.
+type RemoteAlert struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// +optional
+	Severity v1alpha1.AlertSeverity `json:"severity,omitempty"`
+
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +optional
+	ExpirationDate *v1alpha1.ExpirationDate `json:"expirationDate,omitempty"`
+
+	// +optional
+	ShowInInsight *v1alpha1.ShowInInsight `json:"showInInsight,omitempty"`
+
+	// +optional
+	NotificationGroups []v1alpha1.NotificationGroup `json:"notificationGroups,omitempty"`
+
+	// +optional
+	PayloadFilters []string `json:"payloadFilters,omitempty"`
+
+	// +optional
+	Scheduling *v1alpha1.Scheduling `json:"scheduling,omitempty"`
+
+	// +optional
+	AlertType v1alpha1.AlertType `json:"alertType,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`,priority=1
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,priority=1
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Alert is the Schema for the alerts API
+type Alert struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AlertSpec   `json:"spec,omitempty"`
+	Status AlertStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AlertList contains a list of Alert
+type AlertList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Alert `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Alert{}, &AlertList{})
+}
@@ -0,0 +1,364 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+var (
+	_ conversion.Convertible = &Alert{}
+	_ conversion.Convertible = &RuleGroup{}
+	_ conversion.Convertible = &RecordingRuleGroupSet{}
+	_ conversion.Convertible = &OutboundWebhook{}
+	_ conversion.Convertible = &CoralogixAccount{}
+	_ conversion.Convertible = &ClusterCoralogixAccount{}
+)
+
+// SetupConversionWebhookWithManager registers the webhook converting all kinds between v1alpha1 and v1beta1
+// in the webhook server of mgr.
+func SetupConversionWebhookWithManager(mgr ctrl.Manager) error {
+	for _, obj := range []client.Object{
+		&Alert{}, &RuleGroup{}, &RecordingRuleGroupSet{}, &OutboundWebhook{}, &CoralogixAccount{}, &ClusterCoralogixAccount{},
+	} {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(obj).Complete(); err != nil {
+			return fmt.Errorf("error on setting up the conversion of %T: %w", obj, err)
+		}
+	}
+	return nil
+}
+
+// ConvertTo converts the alert to v1alpha1.
+func (src *Alert) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Alert)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1alpha1.AlertSpec{
+		Name:               src.Spec.Name,
+		Description:        src.Spec.Description,
+		Active:             src.Spec.Active,
+		Severity:           src.Spec.Severity,
+		Labels:             src.Spec.Labels,
+		ExpirationDate:     src.Spec.ExpirationDate,
+		NotificationGroups: src.Spec.NotificationGroups,
+		ShowInInsight:      src.Spec.ShowInInsight,
+		PayloadFilters:     src.Spec.PayloadFilters,
+		Scheduling:         src.Spec.Scheduling,
+		AlertType:          src.Spec.AlertType,
+		AccountRef:         src.Spec.AccountRef,
+		DriftPolicy:        src.Spec.DriftPolicy,
+		DeletionPolicy:     src.Spec.DeletionPolicy,
+	}
+	dst.Status = v1alpha1.AlertStatus{ID: convertIDTo(src.Status.ID), SyncStatus: src.Status.SyncStatus}
+	if remote := src.Status.Remote; remote != nil {
+		dst.Status.Name = remote.Name
+		dst.Status.Description = remote.Description
+		dst.Status.Active = remote.Active
+		dst.Status.Severity = remote.Severity
+		dst.Status.Labels = remote.Labels
+		dst.Status.ExpirationDate = remote.ExpirationDate
+		dst.Status.ShowInInsight = remote.ShowInInsight
+		dst.Status.NotificationGroups = remote.NotificationGroups
+		dst.Status.PayloadFilters = remote.PayloadFilters
+		dst.Status.Scheduling = remote.Scheduling
+		dst.Status.AlertType = remote.AlertType
+	}
+	return nil
+}
+
+// ConvertFrom converts the alert from v1alpha1.
+func (dst *Alert) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Alert)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = AlertSpec{
+		Name:               src.Spec.Name,
+		Description:        src.Spec.Description,
+		Active:             src.Spec.Active,
+		Severity:           src.Spec.Severity,
+		Labels:             src.Spec.Labels,
+		ExpirationDate:     src.Spec.ExpirationDate,
+		NotificationGroups: src.Spec.NotificationGroups,
+		ShowInInsight:      src.Spec.ShowInInsight,
+		PayloadFilters:     src.Spec.PayloadFilters,
+		Scheduling:         src.Spec.Scheduling,
+		AlertType:          src.Spec.AlertType,
+		AccountRef:         src.Spec.AccountRef,
+		DriftPolicy:        src.Spec.DriftPolicy,
+		DeletionPolicy:     src.Spec.DeletionPolicy,
+	}
+	dst.Status = AlertStatus{
+		Status: convertStatusFrom(src.Status.ID, src.Status.SyncStatus),
+		Remote: remoteOrNil(&RemoteAlert{
+			Name:               src.Status.Name,
+			Description:        src.Status.Description,
+			Active:             src.Status.Active,
+			Severity:           src.Status.Severity,
+			Labels:             src.Status.Labels,
+			ExpirationDate:     src.Status.ExpirationDate,
+			ShowInInsight:      src.Status.ShowInInsight,
+			NotificationGroups: src.Status.NotificationGroups,
+			PayloadFilters:     src.Status.PayloadFilters,
+			Scheduling:         src.Status.Scheduling,
+			AlertType:          src.Status.AlertType,
+		}),
+	}
+	return nil
+}
+
+// ConvertTo converts the rule group to v1alpha1.
+func (src *RuleGroup) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.RuleGroup)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1alpha1.RuleGroupSpec{
+		Name:           src.Spec.Name,
+		Description:    src.Spec.Description,
+		Active:         src.Spec.Active,
+		Applications:   src.Spec.Applications,
+		Subsystems:     src.Spec.Subsystems,
+		Severities:     src.Spec.Severities,
+		Hidden:         src.Spec.Hidden,
+		Creator:        src.Spec.Creator,
+		Order:          src.Spec.Order,
+		RuleSubgroups:  src.Spec.RuleSubgroups,
+		AccountRef:     src.Spec.AccountRef,
+		DriftPolicy:    src.Spec.DriftPolicy,
+		DeletionPolicy: src.Spec.DeletionPolicy,
+	}
+	dst.Status = v1alpha1.RuleGroupStatus{ID: convertIDTo(src.Status.ID), SyncStatus: src.Status.SyncStatus}
+	if remote := src.Status.Remote; remote != nil {
+		dst.Status.Name = remote.Name
+		dst.Status.Description = remote.Description
+		dst.Status.Active = remote.Active
+		dst.Status.Applications = remote.Applications
+		dst.Status.Subsystems = remote.Subsystems
+		dst.Status.Severities = remote.Severities
+		dst.Status.Hidden = remote.Hidden
+		dst.Status.Creator = remote.Creator
+		dst.Status.Order = remote.Order
+		dst.Status.RuleSubgroups = remote.RuleSubgroups
+	}
+	return nil
+}
+
+// ConvertFrom converts the rule group from v1alpha1.
+func (dst *RuleGroup) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.RuleGroup)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = RuleGroupSpec{
+		Name:           src.Spec.Name,
+		Description:    src.Spec.Description,
+		Active:         src.Spec.Active,
+		Applications:   src.Spec.Applications,
+		Subsystems:     src.Spec.Subsystems,
+		Severities:     src.Spec.Severities,
+		Hidden:         src.Spec.Hidden,
+		Creator:        src.Spec.Creator,
+		Order:          src.Spec.Order,
+		RuleSubgroups:  src.Spec.RuleSubgroups,
+		AccountRef:     src.Spec.AccountRef,
+		DriftPolicy:    src.Spec.DriftPolicy,
+		DeletionPolicy: src.Spec.DeletionPolicy,
+	}
+	dst.Status = RuleGroupStatus{
+		Status: convertStatusFrom(src.Status.ID, src.Status.SyncStatus),
+		Remote: remoteOrNil(&RemoteRuleGroup{
+			Name:          src.Status.Name,
+			Description:   src.Status.Description,
+			Active:        src.Status.Active,
+			Applications:  src.Status.Applications,
+			Subsystems:    src.Status.Subsystems,
+			Severities:    src.Status.Severities,
+			Hidden:        src.Status.Hidden,
+			Creator:       src.Status.Creator,
+			Order:         src.Status.Order,
+			RuleSubgroups: src.Status.RuleSubgroups,
+		}),
+	}
+	return nil
+}
+
+// ConvertTo converts the recording rule group set to v1alpha1.
+func (src *RecordingRuleGroupSet) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.RecordingRuleGroupSet)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1alpha1.RecordingRuleGroupSetSpec{
+		Groups:         src.Spec.Groups,
+		AccountRef:     src.Spec.AccountRef,
+		DriftPolicy:    src.Spec.DriftPolicy,
+		DeletionPolicy: src.Spec.DeletionPolicy,
+	}
+	dst.Status = v1alpha1.RecordingRuleGroupSetStatus{ID: convertIDTo(src.Status.ID), SyncStatus: src.Status.SyncStatus}
+	if remote := src.Status.Remote; remote != nil {
+		dst.Status.Groups = remote.Groups
+	}
+	return nil
+}
+
+// ConvertFrom converts the recording rule group set from v1alpha1.
+func (dst *RecordingRuleGroupSet) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.RecordingRuleGroupSet)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = RecordingRuleGroupSetSpec{
+		Groups:         src.Spec.Groups,
+		AccountRef:     src.Spec.AccountRef,
+		DriftPolicy:    src.Spec.DriftPolicy,
+		DeletionPolicy: src.Spec.DeletionPolicy,
+	}
+	dst.Status = RecordingRuleGroupSetStatus{
+		Status: convertStatusFrom(src.Status.ID, src.Status.SyncStatus),
+		Remote: remoteOrNil(&RemoteRecordingRuleGroupSet{Groups: src.Status.Groups}),
+	}
+	return nil
+}
+
+// ConvertTo converts the outbound webhook to v1alpha1.
+func (src *OutboundWebhook) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.OutboundWebhook)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1alpha1.OutboundWebhookSpec{
+		Name:                src.Spec.Name,
+		OutboundWebhookType: src.Spec.OutboundWebhookType,
+		AccountRef:          src.Spec.AccountRef,
+		DriftPolicy:         src.Spec.DriftPolicy,
+		DeletionPolicy:      src.Spec.DeletionPolicy,
+	}
+	dst.Status = v1alpha1.OutboundWebhookStatus{
+		ID:         convertIDTo(src.Status.ID),
+		ExternalID: convertIDTo(src.Status.ExternalID),
+		LastTest:   src.Status.LastTest,
+		SyncStatus: src.Status.SyncStatus,
+	}
+	if remote := src.Status.Remote; remote != nil {
+		dst.Status.Name = remote.Name
+		dst.Status.OutboundWebhookType = remote.OutboundWebhookType
+	}
+	return nil
+}
+
+// ConvertFrom converts the outbound webhook from v1alpha1.
+func (dst *OutboundWebhook) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.OutboundWebhook)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = OutboundWebhookSpec{
+		Name:                src.Spec.Name,
+		OutboundWebhookType: src.Spec.OutboundWebhookType,
+		AccountRef:          src.Spec.AccountRef,
+		DriftPolicy:         src.Spec.DriftPolicy,
+		DeletionPolicy:      src.Spec.DeletionPolicy,
+	}
+	dst.Status = OutboundWebhookStatus{
+		Status:     convertStatusFrom(src.Status.ID, src.Status.SyncStatus),
+		ExternalID: ptrValue(src.Status.ExternalID),
+		LastTest:   src.Status.LastTest,
+		Remote: remoteOrNil(&RemoteOutboundWebhook{
+			Name:                src.Status.Name,
+			OutboundWebhookType: src.Status.OutboundWebhookType,
+		}),
+	}
+	return nil
+}
+
+// ConvertTo converts the account to v1alpha1.
+func (src *CoralogixAccount) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.CoralogixAccount)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertAccountSpecTo(src.Spec)
+	return nil
+}
+
+// ConvertFrom converts the account from v1alpha1.
+func (dst *CoralogixAccount) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.CoralogixAccount)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertAccountSpecFrom(src.Spec)
+	return nil
+}
+
+// ConvertTo converts the cluster account to v1alpha1.
+func (src *ClusterCoralogixAccount) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ClusterCoralogixAccount)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertAccountSpecTo(src.Spec)
+	return nil
+}
+
+// ConvertFrom converts the cluster account from v1alpha1.
+func (dst *ClusterCoralogixAccount) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ClusterCoralogixAccount)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertAccountSpecFrom(src.Spec)
+	return nil
+}
+
+func convertAccountSpecTo(spec CoralogixAccountSpec) v1alpha1.CoralogixAccountSpec {
+	return v1alpha1.CoralogixAccountSpec{
+		Region: spec.Region,
+		Domain: spec.Domain,
+		APIKeySecretRef: v1alpha1.AccountSecretKeySelector{
+			Name:      spec.APIKeySecretRef.Name,
+			Key:       spec.APIKeySecretRef.Key,
+			Namespace: spec.APIKeySecretRef.Namespace,
+		},
+	}
+}
+
+func convertAccountSpecFrom(spec v1alpha1.CoralogixAccountSpec) CoralogixAccountSpec {
+	return CoralogixAccountSpec{
+		Region: spec.Region,
+		Domain: spec.Domain,
+		APIKeySecretRef: AccountSecretKeySelector{
+			Name:      spec.APIKeySecretRef.Name,
+			Key:       spec.APIKeySecretRef.Key,
+			Namespace: spec.APIKeySecretRef.Namespace,
+		},
+	}
+}
+
+func convertStatusFrom(id *string, syncStatus v1alpha1.SyncStatus) Status {
+	return Status{ID: ptrValue(id), SyncStatus: syncStatus}
+}
+
+// convertIDTo returns the v1alpha1 pointer to id. An empty ID is converted to nil, which v1alpha1 treats the same,
+// as the remote object wasn't created yet.
+func convertIDTo(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+func ptrValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// remoteOrNil returns remote, or nil when the v1alpha1 status had no copy of the remote object.
+func remoteOrNil[T any](remote *T) *T {
+	var zero T
+	if equality.Semantic.DeepEqual(*remote, zero) {
+		return nil
+	}
+	return remote
+}
@@ -0,0 +1,224 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+func v1alpha1Alert() *v1alpha1.Alert {
+	integrationName := "WebhookAlerts"
+	return &v1alpha1.Alert{
+		ObjectMeta: metav1.ObjectMeta{Name: "alert", Namespace: "default", Annotations: map[string]string{"team": "sre"}},
+		Spec: v1alpha1.AlertSpec{
+			Name:     "alert",
+			Severity: v1alpha1.AlertSeverityInfo,
+			AlertType: v1alpha1.AlertType{
+				NewValue: &v1alpha1.NewValue{
+					Conditions: v1alpha1.NewValueConditions{Key: "status", TimeWindow: "TwelveHours"},
+				},
+			},
+			NotificationGroups: []v1alpha1.NotificationGroup{
+				{Notifications: []v1alpha1.Notification{{IntegrationName: &integrationName}}},
+			},
+		},
+	}
+}
+
+func TestAlertConversion(t *testing.T) {
+	withMirror := v1alpha1Alert()
+	withMirror.Status = v1alpha1.AlertStatus{
+		ID:         ptr.To("a1b2"),
+		Name:       "alert",
+		Severity:   v1alpha1.AlertSeverityInfo,
+		Labels:     map[string]string{"managed-by": "coralogix-operator"},
+		SyncStatus: v1alpha1.SyncStatus{ObservedGeneration: 3},
+	}
+	withID := v1alpha1Alert()
+	withID.Status = v1alpha1.AlertStatus{ID: ptr.To("a1b2")}
+
+	for _, tt := range []struct {
+		name       string
+		alert      *v1alpha1.Alert
+		wantRemote bool
+	}{
+		{name: "not created", alert: v1alpha1Alert()},
+		{name: "id only", alert: withID},
+		{name: "mirrored remote alert", alert: withMirror, wantRemote: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			beta := &Alert{}
+			if err := beta.ConvertFrom(tt.alert.DeepCopy()); err != nil {
+				t.Fatalf("unexpected error converting from v1alpha1: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(beta.Spec.AlertType, tt.alert.Spec.AlertType) || beta.Spec.Name != tt.alert.Spec.Name {
+				t.Errorf("expected the spec to be kept, got %+v", beta.Spec)
+			}
+			if got := beta.Status.ID; got != ptr.Deref(tt.alert.Status.ID, "") {
+				t.Errorf("expected the id %v, got %q", tt.alert.Status.ID, got)
+			}
+			if got := beta.Status.Remote != nil; got != tt.wantRemote {
+				t.Errorf("expected the remote alert to be kept: %t, got %+v", tt.wantRemote, beta.Status.Remote)
+			}
+			if !equality.Semantic.DeepEqual(beta.Annotations, tt.alert.Annotations) {
+				t.Errorf("expected the annotations to be kept, got %v", beta.Annotations)
+			}
+
+			alpha := &v1alpha1.Alert{}
+			if err := beta.ConvertTo(alpha); err != nil {
+				t.Fatalf("unexpected error converting to v1alpha1: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(alpha, tt.alert) {
+				t.Errorf("expected the round trip to be lossless, got %+v, want %+v", alpha, tt.alert)
+			}
+		})
+	}
+}
+
+func TestAlertConversionEmptyID(t *testing.T) {
+	alert := v1alpha1Alert()
+	alert.Status.ID = ptr.To("")
+
+	beta := &Alert{}
+	if err := beta.ConvertFrom(alert); err != nil {
+		t.Fatalf("unexpected error converting from v1alpha1: %v", err)
+	}
+	alpha := &v1alpha1.Alert{}
+	if err := beta.ConvertTo(alpha); err != nil {
+		t.Fatalf("unexpected error converting to v1alpha1: %v", err)
+	}
+	if alpha.Status.ID != nil {
+		t.Errorf("expected the empty id to be converted to nil, got %q", *alpha.Status.ID)
+	}
+}
+
+// TestAlertConversionStatusUpdate stores the alert as v1beta1 and updates it the way the v1alpha1 controller does,
+// through the status subresource, which drops any change but the status.
+func TestAlertConversionStatusUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error building the scheme: %v", err)
+	}
+	stored := &Alert{}
+	if err := stored.ConvertFrom(v1alpha1Alert()); err != nil {
+		t.Fatalf("unexpected error converting from v1alpha1: %v", err)
+	}
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stored).WithStatusSubresource(stored).Build()
+
+	get := func() *v1alpha1.Alert {
+		t.Helper()
+		beta := &Alert{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(stored), beta); err != nil {
+			t.Fatalf("unexpected error getting the alert: %v", err)
+		}
+		alpha := &v1alpha1.Alert{}
+		if err := beta.ConvertTo(alpha); err != nil {
+			t.Fatalf("unexpected error converting to v1alpha1: %v", err)
+		}
+		return alpha
+	}
+	updateStatus := func(alpha *v1alpha1.Alert) {
+		t.Helper()
+		beta := &Alert{}
+		if err := beta.ConvertFrom(alpha); err != nil {
+			t.Fatalf("unexpected error converting from v1alpha1: %v", err)
+		}
+		if err := c.Status().Update(ctx, beta); err != nil {
+			t.Fatalf("unexpected error updating the status: %v", err)
+		}
+	}
+
+	for _, description := range []string{"first", "second"} {
+		alpha := get()
+		alpha.Status.ID = ptr.To("a1b2")
+		alpha.Status.Name = "alert"
+		alpha.Status.Description = description
+		alpha.Status.Labels = map[string]string{"managed-by": "coralogix-operator"}
+		want := alpha.Status.DeepCopy()
+		updateStatus(alpha)
+
+		if got := get(); !equality.Semantic.DeepEqual(&got.Status, want) {
+			t.Errorf("expected the status of the last update %+v, got %+v", want, got.Status)
+		}
+	}
+
+	// Editing the object can't change the status.
+	alpha := get()
+	want := alpha.Status.DeepCopy()
+	alpha.Status.Description = "injected"
+	beta := &Alert{}
+	if err := beta.ConvertFrom(alpha); err != nil {
+		t.Fatalf("unexpected error converting from v1alpha1: %v", err)
+	}
+	if err := c.Update(ctx, beta); err != nil {
+		t.Fatalf("unexpected error updating the alert: %v", err)
+	}
+	if got := get(); !equality.Semantic.DeepEqual(&got.Status, want) {
+		t.Errorf("expected the status to be left as %+v, got %+v", want, got.Status)
+	}
+}
+
+func TestOutboundWebhookConversion(t *testing.T) {
+	webhook := &v1alpha1.OutboundWebhook{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook"},
+		Spec: v1alpha1.OutboundWebhookSpec{
+			Name:                "webhook",
+			OutboundWebhookType: v1alpha1.OutboundWebhookType{Slack: &v1alpha1.Slack{Url: "https://hooks.slack.com/services/x"}},
+		},
+		Status: v1alpha1.OutboundWebhookStatus{
+			ID:         ptr.To("w1"),
+			ExternalID: ptr.To("42"),
+			LastTest:   &v1alpha1.OutboundWebhookTest{Trigger: "1", Succeeded: true, StatusCode: ptr.To(int32(200))},
+		},
+	}
+
+	beta := &OutboundWebhook{}
+	if err := beta.ConvertFrom(webhook.DeepCopy()); err != nil {
+		t.Fatalf("unexpected error converting from v1alpha1: %v", err)
+	}
+	if beta.Status.ID != "w1" || beta.Status.ExternalID != "42" || beta.Status.LastTest == nil {
+		t.Errorf("expected the ids and last test to be kept, got %+v", beta.Status)
+	}
+	if beta.Status.Remote != nil {
+		t.Errorf("expected no remote webhook, got %+v", beta.Status.Remote)
+	}
+
+	alpha := &v1alpha1.OutboundWebhook{}
+	if err := beta.ConvertTo(alpha); err != nil {
+		t.Fatalf("unexpected error converting to v1alpha1: %v", err)
+	}
+	if !equality.Semantic.DeepEqual(alpha, webhook) {
+		t.Errorf("expected the round trip to be lossless, got %+v, want %+v", alpha, webhook)
+	}
+}
+
+func TestCoralogixAccountConversion(t *testing.T) {
+	account := &v1alpha1.ClusterCoralogixAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "account"},
+		Spec: v1alpha1.CoralogixAccountSpec{
+			Region:          ptr.To("EU2"),
+			APIKeySecretRef: v1alpha1.AccountSecretKeySelector{Name: "coralogix", Key: "api-key", Namespace: ptr.To("coralogix")},
+		},
+	}
+
+	beta := &ClusterCoralogixAccount{}
+	if err := beta.ConvertFrom(account.DeepCopy()); err != nil {
+		t.Fatalf("unexpected error converting from v1alpha1: %v", err)
+	}
+	alpha := &v1alpha1.ClusterCoralogixAccount{}
+	if err := beta.ConvertTo(alpha); err != nil {
+		t.Fatalf("unexpected error converting to v1alpha1: %v", err)
+	}
+	if !equality.Semantic.DeepEqual(alpha, account) {
+		t.Errorf("expected the round trip to be lossless, got %+v, want %+v", alpha, account)
+	}
+}
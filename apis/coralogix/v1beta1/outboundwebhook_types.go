@@ -0,0 +1,106 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+// OutboundWebhookSpec defines the desired state of OutboundWebhook
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef)",message="accountRef can't be added or removed"
+type OutboundWebhookSpec struct {
+	//+kubebuilder:validation:MinLength=0
+	Name string `json:"name"`
+
+	OutboundWebhookType v1alpha1.OutboundWebhookType `json:"outboundWebhookType"`
+
+	// The Coralogix account the resource is managed in. Defaults to the account the operator was started with.
+	// +optional
+	AccountRef *v1alpha1.AccountReference `json:"accountRef,omitempty"`
+
+	// What to do when the remote outbound webhook is changed outside of the operator, e.g. in the Coralogix UI.
+	// Enforce overwrites the remote outbound webhook with the spec, and Report only records the difference in status.lastDrift.
+	// +optional
+	// +kubebuilder:default=Enforce
+	DriftPolicy *v1alpha1.DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// What to do with the remote outbound webhook when the resource is deleted. Delete deletes it, and Retain leaves it in
+	// Coralogix, e.g. to move the resource to another namespace. Defaults to the operator's deletion policy.
+	// +optional
+	DeletionPolicy *v1alpha1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// OutboundWebhookStatus defines the observed state of OutboundWebhook.
+type OutboundWebhookStatus struct {
+	Status `json:",inline"`
+
+	// The ID alerts refer to the outbound webhook with.
+	// +optional
+	ExternalID string `json:"externalId,omitempty"`
+
+	// The outcome of the last test notification, sent when the app.coralogix.com/test-webhook annotation was changed.
+	// +optional
+	LastTest *v1alpha1.OutboundWebhookTest `json:"lastTest,omitempty"`
+
+	// The remote outbound webhook as of the last reconciliation. The spec is compared with it to tell the changes made
+	// outside of the operator from the changes of the spec.
+	// +optional
+	Remote *RemoteOutboundWebhook `json:"remote,omitempty"`
+}
+
+// RemoteOutboundWebhook is the copy of a remote outbound webhook kept in the status.
+// Its credentials, i.e. tokens, keys, URLs and headers, are only kept as sha256 hashes.
+type RemoteOutboundWebhook struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// +optional
+	OutboundWebhookType *v1alpha1.OutboundWebhookTypeStatus `json:"outboundWebhookType,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`,priority=1
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,priority=1
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// OutboundWebhook is the Schema for the outboundwebhooks API
+type OutboundWebhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OutboundWebhookSpec   `json:"spec,omitempty"`
+	Status OutboundWebhookStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OutboundWebhookList contains a list of OutboundWebhook
+type OutboundWebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OutboundWebhook `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OutboundWebhook{}, &OutboundWebhookList{})
+}
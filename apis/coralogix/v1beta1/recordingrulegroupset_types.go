@@ -0,0 +1,92 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+// RecordingRuleGroupSetSpec defines the desired state of RecordingRuleGroupSet
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef)",message="accountRef can't be added or removed"
+type RecordingRuleGroupSetSpec struct {
+	// +kubebuilder:validation:MinItems=1
+	Groups []v1alpha1.RecordingRuleGroup `json:"groups"`
+
+	// The Coralogix account the resource is managed in. Defaults to the account the operator was started with.
+	// +optional
+	AccountRef *v1alpha1.AccountReference `json:"accountRef,omitempty"`
+
+	// What to do when the remote recording rule group set is changed outside of the operator, e.g. in the Coralogix UI.
+	// Enforce overwrites the remote recording rule group set with the spec, and Report only records the difference in status.lastDrift.
+	// +optional
+	// +kubebuilder:default=Enforce
+	DriftPolicy *v1alpha1.DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// What to do with the remote recording rule group set when the resource is deleted. Delete deletes it, and Retain leaves it in
+	// Coralogix, e.g. to move the resource to another namespace. Defaults to the operator's deletion policy.
+	// +optional
+	DeletionPolicy *v1alpha1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// RecordingRuleGroupSetStatus defines the observed state of RecordingRuleGroupSet
+type RecordingRuleGroupSetStatus struct {
+	Status `json:",inline"`
+
+	// The remote recording rule group set as of the last reconciliation. The spec is compared with it to tell the
+	// changes made outside of the operator from the changes of the spec.
+	// +optional
+	Remote *RemoteRecordingRuleGroupSet `json:"remote,omitempty"`
+}
+
+// RemoteRecordingRuleGroupSet is the copy of a remote recording rule group set kept in the status.
+type RemoteRecordingRuleGroupSet struct {
+	// +optional
+	Groups []v1alpha1.RecordingRuleGroup `json:"groups,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`,priority=1
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,priority=1
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RecordingRuleGroupSet is the Schema for the recordingrulegroupsets API
+type RecordingRuleGroupSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RecordingRuleGroupSetSpec   `json:"spec,omitempty"`
+	Status RecordingRuleGroupSetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RecordingRuleGroupSetList contains a list of RecordingRuleGroupSet
+type RecordingRuleGroupSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RecordingRuleGroupSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RecordingRuleGroupSet{}, &RecordingRuleGroupSetList{})
+}
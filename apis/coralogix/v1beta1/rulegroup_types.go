@@ -0,0 +1,147 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+// RuleGroupSpec defines the Desired state of RuleGroup
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef)",message="accountRef can't be added or removed"
+type RuleGroupSpec struct {
+	//+kubebuilder:validation:MinLength=0
+	Name string `json:"name"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	//+kubebuilder:default=true
+	Active bool `json:"active,omitempty"`
+
+	// +optional
+	Applications []string `json:"applications,omitempty"`
+
+	// +optional
+	Subsystems []string `json:"subsystems,omitempty"`
+
+	// +optional
+	Severities []v1alpha1.RuleSeverity `json:"severities,omitempty"`
+
+	//+kubebuilder:default=false
+	Hidden bool `json:"hidden,omitempty"`
+
+	// +optional
+	Creator string `json:"creator,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	Order *int32 `json:"order,omitempty"`
+
+	// +optional
+	RuleSubgroups []v1alpha1.RuleSubGroup `json:"subgroups,omitempty"`
+
+	// The Coralogix account the resource is managed in. Defaults to the account the operator was started with.
+	// +optional
+	AccountRef *v1alpha1.AccountReference `json:"accountRef,omitempty"`
+
+	// What to do when the remote rule group is changed outside of the operator, e.g. in the Coralogix UI.
+	// Enforce overwrites the remote rule group with the spec, and Report only records the difference in status.lastDrift.
+	// +optional
+	// +kubebuilder:default=Enforce
+	DriftPolicy *v1alpha1.DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// What to do with the remote rule group when the resource is deleted. Delete deletes it, and Retain leaves it in
+	// Coralogix, e.g. to move the resource to another namespace. Defaults to the operator's deletion policy.
+	// +optional
+	DeletionPolicy *v1alpha1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// RuleGroupStatus defines the observed state of RuleGroup
+type RuleGroupStatus struct {
+	Status `json:",inline"`
+
+	// The remote rule group as of the last reconciliation. The spec is compared with it to tell the changes made
+	// outside of the operator from the changes of the spec.
+	// +optional
+	Remote *RemoteRuleGroup `json:"remote,omitempty"`
+}
+
+// RemoteRuleGroup is the copy of a remote rule group kept in the status.
+type RemoteRuleGroup struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// +optional
+	Applications []string `json:"applications,omitempty"`
+
+	// +optional
+	Subsystems []string `json:"subsystems,omitempty"`
+
+	// +optional
+	Severities []v1alpha1.RuleSeverity `json:"severities,omitempty"`
+
+	// +optional
+	Hidden bool `json:"hidden,omitempty"`
+
+	// +optional
+	Creator string `json:"creator,omitempty"`
+
+	// +optional
+	Order *int32 `json:"order,omitempty"`
+
+	// +optional
+	RuleSubgroups []v1alpha1.RuleSubGroup `json:"subgroups,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`,priority=1
+//+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,priority=1
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RuleGroup is the Schema for the rulegroups API
+type RuleGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RuleGroupSpec   `json:"spec,omitempty"`
+	Status RuleGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RuleGroupList contains a list of RuleGroup
+type RuleGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuleGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RuleGroup{}, &RuleGroupList{})
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+// Status is the observed state shared by the resources synced with Coralogix.
+// Unlike v1alpha1, the copy of the remote object is kept apart from it, in the remote field of each kind.
+type Status struct {
+	// The ID of the remote object. Empty until it is created.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	v1alpha1.SyncStatus `json:",inline"`
+}
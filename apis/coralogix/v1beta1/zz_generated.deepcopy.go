@@ -0,0 +1,870 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountSecretKeySelector) DeepCopyInto(out *AccountSecretKeySelector) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountSecretKeySelector.
+func (in *AccountSecretKeySelector) DeepCopy() *AccountSecretKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountSecretKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Alert) DeepCopyInto(out *Alert) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Alert.
+func (in *Alert) DeepCopy() *Alert {
+	if in == nil {
+		return nil
+	}
+	out := new(Alert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Alert) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertList) DeepCopyInto(out *AlertList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Alert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertList.
+func (in *AlertList) DeepCopy() *AlertList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSpec) DeepCopyInto(out *AlertSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExpirationDate != nil {
+		in, out := &in.ExpirationDate, &out.ExpirationDate
+		*out = new(v1alpha1.ExpirationDate)
+		**out = **in
+	}
+	if in.NotificationGroups != nil {
+		in, out := &in.NotificationGroups, &out.NotificationGroups
+		*out = make([]v1alpha1.NotificationGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ShowInInsight != nil {
+		in, out := &in.ShowInInsight, &out.ShowInInsight
+		*out = new(v1alpha1.ShowInInsight)
+		**out = **in
+	}
+	if in.PayloadFilters != nil {
+		in, out := &in.PayloadFilters, &out.PayloadFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(v1alpha1.Scheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	in.AlertType.DeepCopyInto(&out.AlertType)
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(v1alpha1.AccountReference)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(v1alpha1.DriftPolicy)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(v1alpha1.DeletionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSpec.
+func (in *AlertSpec) DeepCopy() *AlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertStatus) DeepCopyInto(out *AlertStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteAlert)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertStatus.
+func (in *AlertStatus) DeepCopy() *AlertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCoralogixAccount) DeepCopyInto(out *ClusterCoralogixAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCoralogixAccount.
+func (in *ClusterCoralogixAccount) DeepCopy() *ClusterCoralogixAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCoralogixAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCoralogixAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCoralogixAccountList) DeepCopyInto(out *ClusterCoralogixAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterCoralogixAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCoralogixAccountList.
+func (in *ClusterCoralogixAccountList) DeepCopy() *ClusterCoralogixAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCoralogixAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCoralogixAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoralogixAccount) DeepCopyInto(out *CoralogixAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoralogixAccount.
+func (in *CoralogixAccount) DeepCopy() *CoralogixAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(CoralogixAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CoralogixAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoralogixAccountList) DeepCopyInto(out *CoralogixAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CoralogixAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoralogixAccountList.
+func (in *CoralogixAccountList) DeepCopy() *CoralogixAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(CoralogixAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CoralogixAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoralogixAccountSpec) DeepCopyInto(out *CoralogixAccountSpec) {
+	*out = *in
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(string)
+		**out = **in
+	}
+	in.APIKeySecretRef.DeepCopyInto(&out.APIKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoralogixAccountSpec.
+func (in *CoralogixAccountSpec) DeepCopy() *CoralogixAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CoralogixAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoralogixAccountStatus) DeepCopyInto(out *CoralogixAccountStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoralogixAccountStatus.
+func (in *CoralogixAccountStatus) DeepCopy() *CoralogixAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CoralogixAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutboundWebhook) DeepCopyInto(out *OutboundWebhook) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundWebhook.
+func (in *OutboundWebhook) DeepCopy() *OutboundWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(OutboundWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OutboundWebhook) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutboundWebhookList) DeepCopyInto(out *OutboundWebhookList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OutboundWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundWebhookList.
+func (in *OutboundWebhookList) DeepCopy() *OutboundWebhookList {
+	if in == nil {
+		return nil
+	}
+	out := new(OutboundWebhookList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OutboundWebhookList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutboundWebhookSpec) DeepCopyInto(out *OutboundWebhookSpec) {
+	*out = *in
+	in.OutboundWebhookType.DeepCopyInto(&out.OutboundWebhookType)
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(v1alpha1.AccountReference)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(v1alpha1.DriftPolicy)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(v1alpha1.DeletionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundWebhookSpec.
+func (in *OutboundWebhookSpec) DeepCopy() *OutboundWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OutboundWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutboundWebhookStatus) DeepCopyInto(out *OutboundWebhookStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.LastTest != nil {
+		in, out := &in.LastTest, &out.LastTest
+		*out = new(v1alpha1.OutboundWebhookTest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteOutboundWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundWebhookStatus.
+func (in *OutboundWebhookStatus) DeepCopy() *OutboundWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OutboundWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleGroupSet) DeepCopyInto(out *RecordingRuleGroupSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleGroupSet.
+func (in *RecordingRuleGroupSet) DeepCopy() *RecordingRuleGroupSet {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleGroupSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecordingRuleGroupSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleGroupSetList) DeepCopyInto(out *RecordingRuleGroupSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RecordingRuleGroupSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleGroupSetList.
+func (in *RecordingRuleGroupSetList) DeepCopy() *RecordingRuleGroupSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleGroupSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecordingRuleGroupSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleGroupSetSpec) DeepCopyInto(out *RecordingRuleGroupSetSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]v1alpha1.RecordingRuleGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(v1alpha1.AccountReference)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(v1alpha1.DriftPolicy)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(v1alpha1.DeletionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleGroupSetSpec.
+func (in *RecordingRuleGroupSetSpec) DeepCopy() *RecordingRuleGroupSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleGroupSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleGroupSetStatus) DeepCopyInto(out *RecordingRuleGroupSetStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteRecordingRuleGroupSet)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleGroupSetStatus.
+func (in *RecordingRuleGroupSetStatus) DeepCopy() *RecordingRuleGroupSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleGroupSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAlert) DeepCopyInto(out *RemoteAlert) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExpirationDate != nil {
+		in, out := &in.ExpirationDate, &out.ExpirationDate
+		*out = new(v1alpha1.ExpirationDate)
+		**out = **in
+	}
+	if in.ShowInInsight != nil {
+		in, out := &in.ShowInInsight, &out.ShowInInsight
+		*out = new(v1alpha1.ShowInInsight)
+		**out = **in
+	}
+	if in.NotificationGroups != nil {
+		in, out := &in.NotificationGroups, &out.NotificationGroups
+		*out = make([]v1alpha1.NotificationGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PayloadFilters != nil {
+		in, out := &in.PayloadFilters, &out.PayloadFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(v1alpha1.Scheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	in.AlertType.DeepCopyInto(&out.AlertType)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAlert.
+func (in *RemoteAlert) DeepCopy() *RemoteAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteOutboundWebhook) DeepCopyInto(out *RemoteOutboundWebhook) {
+	*out = *in
+	if in.OutboundWebhookType != nil {
+		in, out := &in.OutboundWebhookType, &out.OutboundWebhookType
+		*out = new(v1alpha1.OutboundWebhookTypeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteOutboundWebhook.
+func (in *RemoteOutboundWebhook) DeepCopy() *RemoteOutboundWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteOutboundWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteRecordingRuleGroupSet) DeepCopyInto(out *RemoteRecordingRuleGroupSet) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]v1alpha1.RecordingRuleGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteRecordingRuleGroupSet.
+func (in *RemoteRecordingRuleGroupSet) DeepCopy() *RemoteRecordingRuleGroupSet {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteRecordingRuleGroupSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteRuleGroup) DeepCopyInto(out *RemoteRuleGroup) {
+	*out = *in
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subsystems != nil {
+		in, out := &in.Subsystems, &out.Subsystems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]v1alpha1.RuleSeverity, len(*in))
+		copy(*out, *in)
+	}
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RuleSubgroups != nil {
+		in, out := &in.RuleSubgroups, &out.RuleSubgroups
+		*out = make([]v1alpha1.RuleSubGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteRuleGroup.
+func (in *RemoteRuleGroup) DeepCopy() *RemoteRuleGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteRuleGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleGroup) DeepCopyInto(out *RuleGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroup.
+func (in *RuleGroup) DeepCopy() *RuleGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RuleGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleGroupList) DeepCopyInto(out *RuleGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RuleGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupList.
+func (in *RuleGroupList) DeepCopy() *RuleGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RuleGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleGroupSpec) DeepCopyInto(out *RuleGroupSpec) {
+	*out = *in
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subsystems != nil {
+		in, out := &in.Subsystems, &out.Subsystems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]v1alpha1.RuleSeverity, len(*in))
+		copy(*out, *in)
+	}
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RuleSubgroups != nil {
+		in, out := &in.RuleSubgroups, &out.RuleSubgroups
+		*out = make([]v1alpha1.RuleSubGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(v1alpha1.AccountReference)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(v1alpha1.DriftPolicy)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(v1alpha1.DeletionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupSpec.
+func (in *RuleGroupSpec) DeepCopy() *RuleGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleGroupStatus) DeepCopyInto(out *RuleGroupStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteRuleGroup)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleGroupStatus.
+func (in *RuleGroupStatus) DeepCopy() *RuleGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Status) DeepCopyInto(out *Status) {
+	*out = *in
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Status.
+func (in *Status) DeepCopy() *Status {
+	if in == nil {
+		return nil
+	}
+	out := new(Status)
+	in.DeepCopyInto(out)
+	return out
+}
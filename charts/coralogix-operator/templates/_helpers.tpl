@@ -83,3 +83,156 @@ Get the name of the secret key, defaults to CORALOGIX_API_KEY
 {{- .Values.secret.secretKeyReference.key }}
 {{- end }}
 {{- end }}
+
+{{/*
+Get the name of the secret holding the serving certificate of the webhooks
+*/}}
+{{- define "coralogixOperator.webhookCertSecretName" -}}
+{{- if .Values.webhooks.certManager.enabled }}
+{{- printf "%s-webhook-cert" (include "coralogixOperator.fullname" .) }}
+{{- else }}
+{{- required "webhooks.certSecretName is required when cert-manager is not used" .Values.webhooks.certSecretName }}
+{{- end }}
+{{- end }}
+
+{{/*
+Rules of the namespaced resources the operator reconciles, granted in every watched namespace
+*/}}
+{{- define "coralogixOperator.namespacedRules" -}}
+- apiGroups:
+  - coralogix.com
+  resources:
+  - alerts
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - coralogix.com
+  resources:
+  - alerts/finalizers
+  verbs:
+  - update
+- apiGroups:
+  - coralogix.com
+  resources:
+  - alerts/status
+  verbs:
+  - get
+  - patch
+  - update
+- apiGroups:
+  - coralogix.com
+  resources:
+  - recordingrulegroupsets
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - coralogix.com
+  resources:
+  - recordingrulegroupsets/finalizers
+  verbs:
+  - update
+- apiGroups:
+  - coralogix.com
+  resources:
+  - recordingrulegroupsets/status
+  verbs:
+  - get
+  - patch
+  - update
+- apiGroups:
+  - coralogix.com
+  resources:
+  - rulegroups
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - coralogix.com
+  resources:
+  - rulegroups/finalizers
+  verbs:
+  - update
+- apiGroups:
+  - coralogix.com
+  resources:
+  - rulegroups/status
+  verbs:
+  - get
+  - patch
+  - update
+- apiGroups:
+  - coralogix.com
+  resources:
+  - outboundwebhooks
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - coralogix.com
+  resources:
+  - outboundwebhooks/finalizers
+  verbs:
+  - update
+- apiGroups:
+  - coralogix.com
+  resources:
+  - outboundwebhooks/status
+  verbs:
+  - get
+  - patch
+  - update
+- apiGroups:
+  - coralogix.com
+  resources:
+  - coralogixaccounts
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - monitoring.coreos.com
+  resources:
+  - prometheusrules
+  - alertmanagerconfigs
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - events
+  verbs:
+  - create
+  - patch
+{{- end }}
@@ -0,0 +1,202 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
+	"github.com/coralogix/coralogix-operator/controllers/metrics"
+)
+
+//+kubebuilder:rbac:groups=coralogix.com,resources=coralogixaccounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=coralogix.com,resources=clustercoralogixaccounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+// ClientSets resolves the clientset of the Coralogix account a resource refers to.
+// Resources without an account reference use the default clientset, built from the operator's flags.
+type ClientSets struct {
+	client.Reader
+	// Secrets reads the api-key Secrets of the accounts, without caching them, so the operator doesn't keep every
+	// Secret of the cluster in memory.
+	Secrets client.Reader
+	Default clientset.ClientSetInterface
+	// Options are applied to the clientsets of the accounts, e.g. to share the rate limiter of the default one.
+	Options []clientset.Option
+	// WebhookResolvers, when set, forgets the outbound webhooks cached for a clientset once it is closed.
+	WebhookResolvers *clientset.WebhookResolvers
+
+	mu         sync.Mutex
+	clientSets map[accountKey]*accountClientSet
+}
+
+type accountKey struct {
+	kind string
+	types.NamespacedName
+}
+
+type accountClientSet struct {
+	targetUrl    string
+	clientSet    *clientset.ClientSet
+	instrumented clientset.ClientSetInterface
+}
+
+// NewClientSets returns ClientSets that reads accounts using reader, and their Secrets using secrets.
+func NewClientSets(reader, secrets client.Reader, defaultClientSet clientset.ClientSetInterface) *ClientSets {
+	return &ClientSets{
+		Reader:     reader,
+		Secrets:    secrets,
+		Default:    defaultClientSet,
+		clientSets: make(map[accountKey]*accountClientSet),
+	}
+}
+
+// ClientSet returns the clientset of the account referenced by ref, for a resource in namespace.
+// Clientsets are cached per account; a changed endpoint reconnects, and a changed api-key is rotated in place.
+func (c *ClientSets) ClientSet(ctx context.Context, namespace string, ref *coralogixv1alpha1.AccountReference) (clientset.ClientSetInterface, error) {
+	if ref == nil {
+		return c.Default, nil
+	}
+
+	key := accountKey{kind: ref.Kind, NamespacedName: types.NamespacedName{Name: ref.Name}}
+	if key.kind == "" {
+		key.kind = coralogixv1alpha1.CoralogixAccountKind
+	}
+
+	spec, err := c.getAccountSpec(ctx, namespace, &key)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			c.evict(key)
+		}
+		return nil, err
+	}
+
+	targetUrl, err := clientset.TargetUrl(ptr.Deref(spec.Region, ""), ptr.Deref(spec.Domain, ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %s: %w", key.kind, key.NamespacedName, err)
+	}
+
+	apiKey, err := c.getAPIKey(ctx, key, spec.APIKeySecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.clientSets[key]
+	if ok && cached.targetUrl != targetUrl {
+		log.FromContext(ctx).Info("Coralogix account endpoint changed, reconnecting", "account", key.NamespacedName.String(), "kind", key.kind)
+		if err := c.close(cached); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to close the previous connection of the account", "account", key.NamespacedName.String())
+		}
+		ok = false
+	}
+	if !ok {
+		clientSet := clientset.NewClientSet(targetUrl, apiKey, c.Options...)
+		cached = &accountClientSet{targetUrl: targetUrl, clientSet: clientSet, instrumented: metrics.InstrumentClientSet(clientSet)}
+		c.clientSets[key] = cached
+	} else {
+		cached.clientSet.SetAPIKey(apiKey)
+	}
+
+	return cached.instrumented, nil
+}
+
+func (c *ClientSets) getAccountSpec(ctx context.Context, namespace string, key *accountKey) (*coralogixv1alpha1.CoralogixAccountSpec, error) {
+	switch key.kind {
+	case coralogixv1alpha1.CoralogixAccountKind:
+		key.Namespace = namespace
+		account := &coralogixv1alpha1.CoralogixAccount{}
+		if err := c.Get(ctx, key.NamespacedName, account); err != nil {
+			return nil, accountError(key.kind, key.NamespacedName.String(), err)
+		}
+		return &account.Spec, nil
+	case coralogixv1alpha1.ClusterCoralogixAccountKind:
+		account := &coralogixv1alpha1.ClusterCoralogixAccount{}
+		if err := c.Get(ctx, key.NamespacedName, account); err != nil {
+			return nil, accountError(key.kind, key.Name, err)
+		}
+		return &account.Spec, nil
+	default:
+		return nil, fmt.Errorf("unsupported account kind %q", key.kind)
+	}
+}
+
+func accountError(kind, name string, err error) error {
+	if errors.IsNotFound(err) {
+		return &coralogixv1alpha1.AccountNotFoundError{Kind: kind, Name: name, Err: err}
+	}
+	return fmt.Errorf("failed to get %s %s: %w", kind, name, err)
+}
+
+func (c *ClientSets) getAPIKey(ctx context.Context, key accountKey, ref coralogixv1alpha1.AccountSecretKeySelector) (string, error) {
+	secretName := types.NamespacedName{Namespace: key.Namespace, Name: ref.Name}
+	if key.kind == coralogixv1alpha1.ClusterCoralogixAccountKind {
+		if ref.Namespace == nil {
+			return "", fmt.Errorf("the api-key secret of %s %s must have a namespace", key.kind, key.Name)
+		}
+		secretName.Namespace = *ref.Namespace
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Secrets.Get(ctx, secretName, secret); err != nil {
+		return "", fmt.Errorf("failed to get api-key secret %s: %w", secretName, err)
+	}
+
+	apiKey := strings.TrimSpace(string(secret.Data[ref.Key]))
+	if apiKey == "" {
+		return "", fmt.Errorf("key %s not found or empty in secret %s", ref.Key, secretName)
+	}
+
+	return apiKey, nil
+}
+
+func (c *ClientSets) evict(key accountKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.clientSets[key]; ok {
+		_ = c.close(cached)
+		delete(c.clientSets, key)
+	}
+}
+
+// close closes the connection of cached, along with the resolver of its outbound webhooks.
+func (c *ClientSets) close(cached *accountClientSet) error {
+	if c.WebhookResolvers != nil {
+		c.WebhookResolvers.Forget(cached.instrumented.OutboundWebhooks())
+	}
+	return cached.clientSet.Close()
+}
+
+// Start implements manager.Runnable, so the connections of all the accounts are closed when the manager stops.
+func (c *ClientSets) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, cached := range c.clientSets {
+		if err := c.close(cached); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to close the connection of the account", "account", key.NamespacedName.String())
+		}
+		delete(c.clientSets, key)
+	}
+
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (c *ClientSets) NeedLeaderElection() bool {
+	return false
+}
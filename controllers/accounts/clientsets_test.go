@@ -0,0 +1,117 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
+)
+
+func setupClientSets(t *testing.T) (*ClientSets, *clientset.ClientSet) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-key", Namespace: "team-a"},
+			Data:       map[string][]byte{"apiKey": []byte("team-a-key")},
+		},
+		&coralogixv1alpha1.CoralogixAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "team-a"},
+			Spec: coralogixv1alpha1.CoralogixAccountSpec{
+				Region:          ptr.To("EU2"),
+				APIKeySecretRef: coralogixv1alpha1.AccountSecretKeySelector{Name: "api-key", Key: "apiKey"},
+			},
+		},
+		&coralogixv1alpha1.ClusterCoralogixAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "production"},
+			Spec: coralogixv1alpha1.CoralogixAccountSpec{
+				Domain: ptr.To("coralogix.com"),
+				APIKeySecretRef: coralogixv1alpha1.AccountSecretKeySelector{
+					Name: "api-key", Key: "apiKey", Namespace: ptr.To("team-a"),
+				},
+			},
+		},
+		&coralogixv1alpha1.ClusterCoralogixAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-secret-namespace"},
+			Spec: coralogixv1alpha1.CoralogixAccountSpec{
+				Domain:          ptr.To("coralogix.com"),
+				APIKeySecretRef: coralogixv1alpha1.AccountSecretKeySelector{Name: "api-key", Key: "apiKey"},
+			},
+		},
+	).Build()
+
+	defaultClientSet := clientset.NewClientSet("ng-api-grpc.coralogix.com:443", "default-key")
+	t.Cleanup(func() { _ = defaultClientSet.Close() })
+
+	clientSets := NewClientSets(reader, reader, defaultClientSet)
+	return clientSets, defaultClientSet
+}
+
+func TestClientSets(t *testing.T) {
+	ctx := context.Background()
+	clientSets, defaultClientSet := setupClientSets(t)
+
+	t.Run("no account reference uses the default clientset", func(t *testing.T) {
+		cs, err := clientSets.ClientSet(ctx, "team-a", nil)
+		assert.NoError(t, err)
+		assert.Same(t, defaultClientSet, cs)
+	})
+
+	t.Run("namespaced account is resolved in the resource namespace and cached", func(t *testing.T) {
+		ref := &coralogixv1alpha1.AccountReference{Name: "staging"}
+		cs, err := clientSets.ClientSet(ctx, "team-a", ref)
+		assert.NoError(t, err)
+		assert.NotSame(t, defaultClientSet, cs)
+
+		cached, err := clientSets.ClientSet(ctx, "team-a", ref)
+		assert.NoError(t, err)
+		assert.Same(t, cs, cached)
+
+		_, err = clientSets.ClientSet(ctx, "team-b", ref)
+		var accountNotFound *coralogixv1alpha1.AccountNotFoundError
+		assert.ErrorAs(t, err, &accountNotFound)
+	})
+
+	t.Run("cluster account is resolved from any namespace", func(t *testing.T) {
+		ref := &coralogixv1alpha1.AccountReference{Kind: coralogixv1alpha1.ClusterCoralogixAccountKind, Name: "production"}
+		cs, err := clientSets.ClientSet(ctx, "team-b", ref)
+		assert.NoError(t, err)
+		assert.NotNil(t, cs)
+	})
+
+	t.Run("cluster account without secret namespace is rejected", func(t *testing.T) {
+		ref := &coralogixv1alpha1.AccountReference{Kind: coralogixv1alpha1.ClusterCoralogixAccountKind, Name: "no-secret-namespace"}
+		_, err := clientSets.ClientSet(ctx, "team-a", ref)
+		assert.Error(t, err)
+	})
+
+	t.Run("deleted account forgets the outbound webhooks of its clientset", func(t *testing.T) {
+		resolvers := clientset.NewWebhookResolvers(time.Minute)
+		clientSets.WebhookResolvers = resolvers
+		ref := &coralogixv1alpha1.AccountReference{Name: "staging"}
+		cs, err := clientSets.ClientSet(ctx, "team-a", ref)
+		assert.NoError(t, err)
+		resolver := resolvers.For(cs.OutboundWebhooks())
+
+		account := &coralogixv1alpha1.CoralogixAccount{ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "team-a"}}
+		assert.NoError(t, clientSets.Reader.(client.Client).Delete(ctx, account))
+		_, err = clientSets.ClientSet(ctx, "team-a", ref)
+		var accountNotFound *coralogixv1alpha1.AccountNotFoundError
+		assert.ErrorAs(t, err, &accountNotFound)
+		assert.NotSame(t, resolver, resolvers.For(cs.OutboundWebhooks()))
+	})
+}
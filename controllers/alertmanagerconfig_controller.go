@@ -7,6 +7,7 @@ import (
 	"time"
 
 	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	"github.com/coralogix/coralogix-operator/controllers/alphacontrollers"
 	"github.com/coralogix/coralogix-operator/controllers/clientset"
 	"github.com/go-logr/logr"
 	prometheus "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
@@ -15,9 +16,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/utils/pointer"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -40,6 +43,11 @@ type AlertmanagerConfigReconciler struct {
 	client.Client
 	CoralogixClientSet clientset.ClientSetInterface
 	Scheme             *runtime.Scheme
+	Recorder           record.EventRecorder
+	// MaxConcurrentReconciles is the number of resources reconciled in parallel. Zero means one.
+	MaxConcurrentReconciles int
+	// WatchFilter restricts the reconciled AlertmanagerConfigs to some namespaces and labels. The zero value reconciles all of them.
+	WatchFilter alphacontrollers.WatchFilter
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -52,7 +60,8 @@ func (r *AlertmanagerConfigReconciler) SetupWithManager(mgr ctrl.Manager) error
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&prometheus.AlertmanagerConfig{}).
+		For(&prometheus.AlertmanagerConfig{}, builder.WithPredicates(r.WatchFilter.Predicate())).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		WithEventFilter(predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool {
 				return shouldTrackAlertmanagerConfigs(e.Object.GetLabels())
@@ -100,6 +109,8 @@ func (r *AlertmanagerConfigReconciler) convertAlertmanagerConfigToCxIntegrations
 	outboundWebhook := &coralogixv1alpha1.OutboundWebhook{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: alertmanagerConfig.Namespace,
+			// The outbound-webhooks keep the labels selecting the AlertmanagerConfig, for the operator to watch them too.
+			Labels: r.WatchFilter.SelectedLabels(alertmanagerConfig.Labels),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion: alertmanagerConfig.APIVersion,
@@ -124,17 +135,21 @@ func (r *AlertmanagerConfigReconciler) convertAlertmanagerConfigToCxIntegrations
 					if err = r.Create(ctx, opsGenieWebhook); err != nil {
 						succeed = false
 						log.Error(err, "Received an error while trying to create OutboundWebhook CRD from alertmanagerConfig")
+						r.Recorder.Eventf(alertmanagerConfig, v1.EventTypeWarning, "ConversionFailed", "Failed to create OutboundWebhook CRD from alertmanagerConfig: %v", err)
 						continue
 					}
+					r.Recorder.Eventf(alertmanagerConfig, v1.EventTypeNormal, "OutboundWebhookCreated", "Created OutboundWebhook %s", opsGenieWebhook.Name)
 				} else {
 					succeed = false
 					log.Error(err, "Received an error while trying to get OutboundWebhook CRD from alertmanagerConfig")
+					r.Recorder.Eventf(alertmanagerConfig, v1.EventTypeWarning, "ConversionFailed", "Failed to get OutboundWebhook CRD from alertmanagerConfig: %v", err)
 					continue
 				}
 			} else {
 				if err = r.Update(ctx, opsGenieWebhook); err != nil {
 					succeed = false
 					log.Error(err, "Received an error while trying to update OutboundWebhook CRD from alertmanagerConfig")
+					r.Recorder.Eventf(alertmanagerConfig, v1.EventTypeWarning, "ConversionFailed", "Failed to update OutboundWebhook CRD from alertmanagerConfig: %v", err)
 					continue
 				}
 			}
@@ -148,6 +163,7 @@ func (r *AlertmanagerConfigReconciler) convertAlertmanagerConfigToCxIntegrations
 					if err != nil {
 						succeed = false
 						log.Error(err, "Received an error while trying to convert SlackConfig to OutboundWebhookType")
+						r.Recorder.Eventf(alertmanagerConfig, v1.EventTypeWarning, "ConversionFailed", "Failed to convert SlackConfig to OutboundWebhookType: %v", err)
 						continue
 					}
 					slackWebhook.Spec = coralogixv1alpha1.OutboundWebhookSpec{
@@ -157,17 +173,21 @@ func (r *AlertmanagerConfigReconciler) convertAlertmanagerConfigToCxIntegrations
 					if err = r.Create(ctx, slackWebhook); err != nil {
 						succeed = false
 						log.Error(err, "Received an error while trying to create OutboundWebhook CRD from alertmanagerConfig")
+						r.Recorder.Eventf(alertmanagerConfig, v1.EventTypeWarning, "ConversionFailed", "Failed to create OutboundWebhook CRD from alertmanagerConfig: %v", err)
 						continue
 					}
+					r.Recorder.Eventf(alertmanagerConfig, v1.EventTypeNormal, "OutboundWebhookCreated", "Created OutboundWebhook %s", slackWebhook.Name)
 				} else {
 					succeed = false
 					log.Error(err, "Received an error while trying to get OutboundWebhook CRD from alertmanagerConfig")
+					r.Recorder.Eventf(alertmanagerConfig, v1.EventTypeWarning, "ConversionFailed", "Failed to get OutboundWebhook CRD from alertmanagerConfig: %v", err)
 					continue
 				}
 			} else {
 				if err = r.Update(ctx, slackWebhook); err != nil {
 					succeed = false
 					log.Error(err, "Received an error while trying to update OutboundWebhook CRD from alertmanagerConfig")
+					r.Recorder.Eventf(alertmanagerConfig, v1.EventTypeWarning, "ConversionFailed", "Failed to update OutboundWebhook CRD from alertmanagerConfig: %v", err)
 					continue
 				}
 			}
@@ -222,6 +242,7 @@ func (r *AlertmanagerConfigReconciler) linkCxAlertToCxIntegrations(ctx context.C
 	var alerts coralogixv1alpha1.AlertList
 	if err := r.List(ctx, &alerts, client.InNamespace(config.Namespace), client.MatchingLabels{"app.coralogix.com/managed-by-alertmanger-config": "true"}); err != nil {
 		log.Error(err, "Received an error while trying to list Alerts")
+		r.Recorder.Eventf(config, v1.EventTypeWarning, "ConversionFailed", "Failed to list Alerts: %v", err)
 		return false
 	}
 
@@ -231,6 +252,7 @@ func (r *AlertmanagerConfigReconciler) linkCxAlertToCxIntegrations(ctx context.C
 		if err != nil {
 			succeed = false
 			log.Error(err, "Received an error while trying to match routes")
+			r.Recorder.Eventf(config, v1.EventTypeWarning, "ConversionFailed", "Failed to match routes: %v", err)
 			continue
 		}
 
@@ -239,11 +261,13 @@ func (r *AlertmanagerConfigReconciler) linkCxAlertToCxIntegrations(ctx context.C
 		if err != nil {
 			succeed = false
 			log.Error(err, "Received an error while trying to generate NotificationGroup from routes")
+			r.Recorder.Eventf(config, v1.EventTypeWarning, "ConversionFailed", "Failed to generate NotificationGroup from routes: %v", err)
 			continue
 		}
 		if err = r.Update(ctx, &alert); err != nil {
 			succeed = false
 			log.Error(err, "Received an error while trying to update Alert CRD from AlertmanagerConfig")
+			r.Recorder.Eventf(config, v1.EventTypeWarning, "ConversionFailed", "Failed to update Alert CRD from AlertmanagerConfig: %v", err)
 			continue
 		}
 	}
@@ -342,7 +366,8 @@ func webhookNameToAlertNotification(webhookName string, retriggeringPeriodMinute
 		notifyOn = coralogixv1alpha1.NotifyOnTriggeredOnly
 	}
 	return coralogixv1alpha1.Notification{
-		IntegrationName:           pointer.String(webhookName),
+		// The outbound webhooks are created in the namespace of the AlertmanagerConfig, along with the alerts.
+		IntegrationRef:            &coralogixv1alpha1.OutboundWebhookReference{Name: webhookName},
 		RetriggeringPeriodMinutes: retriggeringPeriodMinutes,
 		NotifyOn:                  coralogixv1alpha1.NotifyOn(notifyOn),
 	}
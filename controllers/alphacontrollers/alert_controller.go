@@ -25,18 +25,24 @@ import (
 	"github.com/go-logr/logr"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-
-	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	utils "github.com/coralogix/coralogix-operator/apis"
 	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
@@ -61,24 +67,45 @@ var (
 type AlertReconciler struct {
 	client.Client
 	CoralogixClientSet clientset.ClientSetInterface
+	Accounts           ClientSetResolver
 	Scheme             *runtime.Scheme
+	// ResyncPeriod is how often the remote alert is compared with the spec, to catch out-of-band changes.
+	// Zero disables the periodic resync.
+	ResyncPeriod time.Duration
+	Recorder     record.EventRecorder
+	// MaxConcurrentReconciles is the number of resources reconciled in parallel. Zero means one.
+	MaxConcurrentReconciles int
+	// DeletionPolicy is what happens to the remote alert of a deleted resource without a deletion policy.
+	// Empty means Delete.
+	DeletionPolicy coralogixv1alpha1.DeletionPolicy
+	// DryRun records the changes that would be made to the remote alerts in the status and events, instead of making them.
+	DryRun bool
+	// WatchFilter restricts the reconciled alerts to some namespaces and labels. The zero value reconciles all of them.
+	WatchFilter WatchFilter
+	// WebhookResolvers caches the outbound webhooks of each account, which notifications refer to by name.
+	// When nil, they are listed on each reconciliation.
+	WebhookResolvers *clientset.WebhookResolvers
+}
+
+// webhookResolver returns the resolver of the outbound webhooks of the account of clientSet.
+func (r *AlertReconciler) webhookResolver(clientSet clientset.ClientSetInterface) coralogixv1alpha1.WebhookResolver {
+	if r.WebhookResolvers == nil {
+		return clientset.NewWebhookResolver(clientSet.OutboundWebhooks(), 0)
+	}
+	return r.WebhookResolvers.For(clientSet.OutboundWebhooks())
 }
 
 //+kubebuilder:rbac:groups=coralogix.com,resources=alerts,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=coralogix.com,resources=alerts/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=coralogix.com,resources=alerts/finalizers,verbs=update
 
-func (r *AlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	var (
-		err error
-	)
+func (r *AlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := log.FromContext(ctx).WithValues(
 		"alert", req.NamespacedName.Name,
 		"namespace", req.NamespacedName.Namespace,
 	)
 
 	log.V(1).Info("Reconciling Alert")
-	coralogixv1alpha1.WebhooksClient = r.CoralogixClientSet.OutboundWebhooks()
 	alert := coralogixv1alpha1.NewAlert()
 
 	if err = r.Client.Get(ctx, req.NamespacedName, alert); err != nil {
@@ -90,18 +117,78 @@ func (r *AlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 	}
+	// Alerts admitted without the defaulting webhook are sent with the same defaults, without being updated.
+	alert.Spec.Default()
+
+	if paused, pauseErr := reconcilePaused(ctx, r.Client, r.Recorder, alert); pauseErr != nil {
+		log.Error(pauseErr, "Error on checking whether the alert reconciliation is paused")
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, pauseErr
+	} else if paused {
+		// Neither changes nor deletions are propagated until the reconciliation is resumed.
+		log.V(1).Info("Alert reconciliation is paused")
+		return ctrl.Result{}, nil
+	}
+
+	// The plan is recomputed on each reconciliation.
+	alert.Status.Plan = nil
+	defer func() {
+		if statusErr := updateSyncStatus(ctx, r.Client, alert, r.DryRun, err); statusErr != nil {
+			log.Error(statusErr, "Error on updating alert sync status")
+			if err == nil {
+				result, err = ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, statusErr
+			}
+		}
+	}()
+
+	clientSet, err := resolveClientSet(ctx, r.Accounts, r.CoralogixClientSet, alert.Namespace, alert.Spec.AccountRef)
+	if err != nil {
+		var released bool
+		if released, err = releaseWithoutAccount(ctx, r.Client, r.Recorder, alert, alertFinalizerName, "alert", err); released {
+			log.Info("Account of the alert was deleted, leaving the remote alert in Coralogix")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Error on resolving Coralogix account")
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+	webhooks := coralogixv1alpha1.NotificationWebhooks{Resolver: r.webhookResolver(clientSet)}
+
+	// The outbound webhooks an alert notifies don't have to be ready for it to be deleted.
+	if alert.ObjectMeta.DeletionTimestamp.IsZero() {
+		webhooks.RefIDs, err = coralogixv1alpha1.ResolveIntegrationRefs(ctx, r.Client, alert)
+		if err != nil {
+			recordInvalidSpec(r.Recorder, alert, "alert", err)
+			log.Error(err, "Error on resolving the outbound webhooks of the alert")
+			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+		}
+	}
 
 	if ptr.Deref(alert.Status.ID, "") == "" {
-		err = r.create(ctx, log, alert)
+		var id string
+		if id, err = pendingImportID(ctx, r.Client, alert, &coralogixv1alpha1.AlertList{}); err != nil {
+			recordInvalidSpec(r.Recorder, alert, "alert", err)
+			log.Error(err, "Error on checking the alert to import")
+			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+		}
+		if id != "" {
+			err = r.importRemote(ctx, log, clientSet, webhooks, alert, id)
+			if err != nil {
+				log.Error(err, "Error on importing alert", "id", id)
+				return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+			}
+			// The imported alert is reconciled to the spec right away.
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		err = r.create(ctx, log, clientSet, webhooks, alert)
 		if err != nil {
 			log.Error(err, "Error on creating alert")
 			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 		}
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
 	}
 
 	if !alert.ObjectMeta.DeletionTimestamp.IsZero() {
-		err = r.delete(ctx, log, alert)
+		err = r.delete(ctx, log, clientSet, alert)
 		if err != nil {
 			log.Error(err, "Error on deleting alert")
 			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
@@ -109,39 +196,102 @@ func (r *AlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, nil
 	}
 
-	err = r.update(ctx, log, alert)
+	err = r.update(ctx, log, clientSet, webhooks, alert)
 	if err != nil {
 		log.Error(err, "Error on updating alert")
 		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
 }
 
 func (r *AlertReconciler) update(ctx context.Context,
 	log logr.Logger,
+	clientSet clientset.ClientSetInterface,
+	webhooks coralogixv1alpha1.NotificationWebhooks,
 	alert *coralogixv1alpha1.Alert) error {
-	alertRequest, err := alert.Spec.ExtractUpdateAlertRequest(ctx, log, *alert.Status.ID)
+	log.V(1).Info("Getting remote alert", "id", *alert.Status.ID)
+	remoteAlert, err := clientSet.Alerts().GetAlert(ctx, &alerts.GetAlertByUniqueIdRequest{
+		Id: wrapperspb.String(*alert.Status.ID),
+	})
 	if err != nil {
-		return fmt.Errorf("error to parse alert request: %w", err)
+		if status.Code(err) == codes.NotFound {
+			return r.resetStatus(ctx, log, alert, err)
+		}
+		return fmt.Errorf("error on getting alert: %w", err)
+	}
+	log.V(1).Info("Remote alert found", "alert", redactedJSON(remoteAlert))
+
+	var equal bool
+	var diff utils.Diff
+	actualStatus, err := getStatus(ctx, remoteAlert.GetAlert(), alert.Spec, webhooks)
+	var unresolvedWebhook *coralogixv1alpha1.UnresolvedWebhookError
+	switch {
+	case stdErr.As(err, &unresolvedWebhook) && unresolvedWebhook.Name == "":
+		// The remote alert notifies an outbound webhook that was deleted since, which the spec can't refer to.
+		diff = utils.Diff{Name: "Notifications.IntegrationId", Actual: unresolvedWebhook.ID}
+	case err != nil:
+		return fmt.Errorf("error on getting status: %w", err)
+	default:
+		equal, diff = alert.Spec.DeepEqual(&actualStatus)
+	}
+	if equal {
+		log.V(1).Info("Remote alert is up to date")
+		actualStatus.SyncStatus = alert.Status.SyncStatus
+		actualStatus.LastDrift = resolvedDrift(alert.Status.LastDrift)
+		if equality.Semantic.DeepEqual(alert.Status, actualStatus) {
+			return nil
+		}
+		alert.Status = actualStatus
+		if err = r.Status().Update(ctx, alert); err != nil {
+			return fmt.Errorf("error on updating alert status: %w", err)
+		}
+		return nil
 	}
+	log.V(1).Info("Found diff between spec and remote alert", "diff", diff)
 
-	log.V(1).Info("Updating remote alert", "alert", protojson.Format(alertRequest))
-	remoteUpdatedAlert, err := r.CoralogixClientSet.Alerts().UpdateAlert(ctx, alertRequest)
-	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			log.Info("alert not found on remote, recreating it")
-			alert.Status = *coralogixv1alpha1.NewDefaultAlertStatus()
+	// The spec was applied already, so the remote alert was changed outside of the operator.
+	if specApplied, _ := alert.Spec.DeepEqual(&alert.Status); specApplied {
+		drift := coralogixv1alpha1.NewDrift(diff, alert.Spec.DriftPolicy.Enforces())
+		if !drift.Enforced {
+			log.Info("Remote alert drifted from the spec, reporting it only", "field", drift.Field)
+			if drift.SameAs(alert.Status.LastDrift) {
+				return nil
+			}
+			recordDrift(r.Recorder, alert, "alert", drift)
+			alert.Status.LastDrift = drift
 			if err = r.Status().Update(ctx, alert); err != nil {
 				return fmt.Errorf("error on updating alert status: %w", err)
 			}
-			return fmt.Errorf("alert not found on remote, recreating it: %w", err)
+			return nil
+		}
+		recordDrift(r.Recorder, alert, "alert", drift)
+	}
+
+	alertRequest, err := alert.Spec.ExtractUpdateAlertRequest(ctx, *alert.Status.ID, webhooks)
+	if err != nil {
+		recordInvalidSpec(r.Recorder, alert, "alert", err)
+		return fmt.Errorf("error to parse alert request: %w", err)
+	}
+
+	if r.DryRun {
+		log.Info("Dry run: not updating remote alert", "id", *alert.Status.ID, "field", diff.Name)
+		recordPlan(r.Recorder, alert, "alert", coralogixv1alpha1.PlanActionUpdate, diff.Name, alertRequest)
+		return nil
+	}
+
+	log.V(1).Info("Updating remote alert", "alert", redactedJSON(alertRequest))
+	remoteUpdatedAlert, err := clientSet.Alerts().UpdateAlert(ctx, alertRequest)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return r.resetStatus(ctx, log, alert, err)
 		}
 		return fmt.Errorf("error on updating alert: %w", err)
 	}
-	log.V(1).Info("Remote alert updated", "alert", protojson.Format(remoteUpdatedAlert))
+	log.V(1).Info("Remote alert updated", "alert", redactedJSON(remoteUpdatedAlert))
+	r.Recorder.Eventf(alert, corev1.EventTypeNormal, ReasonUpdated, "Remote alert %s was updated", *alert.Status.ID)
 
-	status, err := getStatus(ctx, log, remoteUpdatedAlert.GetAlert(), alert.Spec)
+	status, err := getStatus(ctx, remoteUpdatedAlert.GetAlert(), alert.Spec, webhooks)
 	if err != nil {
 		return fmt.Errorf("error on getting status: %w", err)
 	}
@@ -149,6 +299,8 @@ func (r *AlertReconciler) update(ctx context.Context,
 	if err = r.Get(ctx, client.ObjectKeyFromObject(alert), alert); err != nil {
 		return fmt.Errorf("error on getting alert: %w", err)
 	}
+	status.SyncStatus = alert.Status.SyncStatus
+	status.LastDrift = coralogixv1alpha1.NewDrift(diff, true)
 	alert.Status = status
 
 	if err = r.Status().Update(ctx, alert); err != nil {
@@ -158,21 +310,45 @@ func (r *AlertReconciler) update(ctx context.Context,
 	return nil
 }
 
+// resetStatus clears the status of an alert that was not found on remote, so it is recreated on the next reconcile.
+func (r *AlertReconciler) resetStatus(ctx context.Context, log logr.Logger, alert *coralogixv1alpha1.Alert, notFoundErr error) error {
+	log.Info("alert not found on remote, recreating it")
+	r.Recorder.Eventf(alert, corev1.EventTypeWarning, ReasonRemoteNotFound, "Remote alert %s was not found, recreating it", ptr.Deref(alert.Status.ID, ""))
+	status := coralogixv1alpha1.NewDefaultAlertStatus()
+	status.SyncStatus = alert.Status.SyncStatus
+	alert.Status = *status
+	if err := r.Status().Update(ctx, alert); err != nil {
+		return fmt.Errorf("error on updating alert status: %w", err)
+	}
+	return fmt.Errorf("alert not found on remote, recreating it: %w", notFoundErr)
+}
+
 func (r *AlertReconciler) delete(ctx context.Context,
 	log logr.Logger,
+	clientSet clientset.ClientSetInterface,
 	alert *coralogixv1alpha1.Alert) error {
 
-	log.V(1).Info("Deleting remote alert", "alert", *alert.Status.ID)
-	_, err := r.CoralogixClientSet.Alerts().DeleteAlert(ctx, &alerts.DeleteAlertByUniqueIdRequest{
-		Id: wrapperspb.String(*alert.Status.ID),
-	})
-	if err != nil && status.Code(err) != codes.NotFound {
-		return fmt.Errorf("error on deleting alert: %w", err)
+	if alert.Spec.DeletionPolicy.Retains(r.DeletionPolicy) {
+		log.V(1).Info("Retaining remote alert", "alert", *alert.Status.ID)
+		r.Recorder.Eventf(alert, corev1.EventTypeNormal, ReasonRetained, "Remote alert %s was retained", *alert.Status.ID)
+	} else if r.DryRun {
+		// The resource is not kept until the operator leaves dry-run mode, so the remote alert is left as is.
+		log.Info("Dry run: not deleting remote alert", "alert", *alert.Status.ID)
+		recordPlan(r.Recorder, alert, "alert", coralogixv1alpha1.PlanActionDelete, "", nil)
+	} else {
+		log.V(1).Info("Deleting remote alert", "alert", *alert.Status.ID)
+		_, err := clientSet.Alerts().DeleteAlert(ctx, &alerts.DeleteAlertByUniqueIdRequest{
+			Id: wrapperspb.String(*alert.Status.ID),
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("error on deleting alert: %w", err)
+		}
+		log.V(1).Info("Remote alert deleted", "alert", *alert.Status.ID)
+		r.Recorder.Eventf(alert, corev1.EventTypeNormal, ReasonDeleted, "Remote alert %s was deleted", *alert.Status.ID)
 	}
-	log.V(1).Info("Remote alert deleted", "alert", *alert.Status.ID)
 
-	controllerutil.RemoveFinalizer(alert, alertFinalizerName)
-	if err = r.Update(ctx, alert); err != nil {
+	// The spec of the alert is defaulted in memory, so only the finalizers are persisted.
+	if err := patchFinalizers(ctx, r.Client, alert, controllerutil.RemoveFinalizer, alertFinalizerName); err != nil {
 		return fmt.Errorf("error on updating alert: %w", err)
 	}
 
@@ -182,59 +358,92 @@ func (r *AlertReconciler) delete(ctx context.Context,
 func (r *AlertReconciler) create(
 	ctx context.Context,
 	log logr.Logger,
+	clientSet clientset.ClientSetInterface,
+	webhooks coralogixv1alpha1.NotificationWebhooks,
 	alert *coralogixv1alpha1.Alert) error {
 
-	if alert.Spec.Labels == nil {
-		alert.Spec.Labels = make(map[string]string)
-	}
-
-	if value, ok := alert.Spec.Labels["managed-by"]; !ok || value == "" {
-		alert.Spec.Labels["managed-by"] = "coralogix-operator"
-	}
-
-	if err := r.Update(ctx, alert); err != nil {
-		return fmt.Errorf("error on updating alert: %w", err)
-	}
-
-	alertRequest, err := alert.ExtractCreateAlertRequest(ctx, log)
+	alertRequest, err := alert.ExtractCreateAlertRequest(ctx, webhooks)
 	if err != nil {
+		recordInvalidSpec(r.Recorder, alert, "alert", err)
 		return fmt.Errorf("error to parse alert request: %w", err)
 	}
 
-	log.V(1).Info("Creating remote alert", "alert", protojson.Format(alertRequest))
-	response, err := r.CoralogixClientSet.Alerts().CreateAlert(ctx, alertRequest)
+	if r.DryRun {
+		log.Info("Dry run: not creating remote alert")
+		recordPlan(r.Recorder, alert, "alert", coralogixv1alpha1.PlanActionCreate, "", alertRequest)
+		return nil
+	}
+
+	log.V(1).Info("Creating remote alert", "alert", redactedJSON(alertRequest))
+	response, err := clientSet.Alerts().CreateAlert(ctx, alertRequest)
 	if err != nil {
 		return fmt.Errorf("error on creating alert: %w", err)
 	}
-	log.V(1).Info("Remote alert created", "response", protojson.Format(response))
+	log.V(1).Info("Remote alert created", "response", redactedJSON(response))
+	r.Recorder.Eventf(alert, corev1.EventTypeNormal, ReasonCreated, "Remote alert %s was created", response.GetAlert().GetUniqueIdentifier().GetValue())
 
+	// The refetched alert isn't defaulted, and the status is computed from the spec that was sent.
+	spec := alert.Spec
 	if err = r.Get(ctx, client.ObjectKeyFromObject(alert), alert); err != nil {
 		return fmt.Errorf("error on getting alert: %w", err)
 	}
 
-	alert.Status.ID = pointer.String(response.GetAlert().GetUniqueIdentifier().GetValue())
-	if err = r.Update(ctx, alert); err != nil {
+	status, err := getStatus(ctx, response.GetAlert(), spec, webhooks)
+	if err != nil {
+		return fmt.Errorf("error on getting status: %w", err)
+	}
+	status.SyncStatus = alert.Status.SyncStatus
+	alert.Status = status
+	if err = r.Status().Update(ctx, alert); err != nil {
+		return fmt.Errorf("error on updating alert status: %w", err)
+	}
+
+	if err = patchFinalizers(ctx, r.Client, alert, controllerutil.AddFinalizer, alertFinalizerName); err != nil {
 		return fmt.Errorf("error on updating alert: %w", err)
 	}
 
-	if alert.Status, err = getStatus(ctx, log, response.GetAlert(), alert.Spec); err != nil {
+	return nil
+}
+
+// importRemote binds the alert to the existing remote alert id, instead of creating one.
+func (r *AlertReconciler) importRemote(
+	ctx context.Context,
+	log logr.Logger,
+	clientSet clientset.ClientSetInterface,
+	webhooks coralogixv1alpha1.NotificationWebhooks,
+	alert *coralogixv1alpha1.Alert,
+	id string) error {
+
+	log.V(1).Info("Importing remote alert", "id", id)
+	remoteAlert, err := clientSet.Alerts().GetAlert(ctx, &alerts.GetAlertByUniqueIdRequest{
+		Id: wrapperspb.String(id),
+	})
+	if err != nil {
+		return fmt.Errorf("error on getting alert %s to import: %w", id, err)
+	}
+	log.V(1).Info("Remote alert found", "alert", redactedJSON(remoteAlert))
+
+	status, err := getStatus(ctx, remoteAlert.GetAlert(), alert.Spec, webhooks)
+	if err != nil {
 		return fmt.Errorf("error on getting status: %w", err)
 	}
+	status.SyncStatus = alert.Status.SyncStatus
+	status.ImportedID = id
+	alert.Status = status
 	if err = r.Status().Update(ctx, alert); err != nil {
 		return fmt.Errorf("error on updating alert status: %w", err)
 	}
+	r.Recorder.Eventf(alert, corev1.EventTypeNormal, ReasonImported, "Remote alert %s was imported", id)
 
-	if !controllerutil.ContainsFinalizer(alert, alertFinalizerName) {
-		controllerutil.AddFinalizer(alert, alertFinalizerName)
-	}
-	if err = r.Client.Update(ctx, alert); err != nil {
+	// The spec of the alert is defaulted in memory, so only the finalizers are persisted.
+	if err = patchFinalizers(ctx, r.Client, alert, controllerutil.AddFinalizer, alertFinalizerName); err != nil {
 		return fmt.Errorf("error on updating alert: %w", err)
 	}
 
 	return nil
 }
 
-func getStatus(ctx context.Context, log logr.Logger, actualAlert *alerts.Alert, spec coralogixv1alpha1.AlertSpec) (coralogixv1alpha1.AlertStatus, error) {
+func getStatus(ctx context.Context, actualAlert *alerts.Alert, spec coralogixv1alpha1.AlertSpec, webhooks coralogixv1alpha1.NotificationWebhooks) (coralogixv1alpha1.AlertStatus, error) {
 	if actualAlert == nil {
 		return coralogixv1alpha1.AlertStatus{}, stdErr.New("alert is nil")
 	}
@@ -265,7 +474,7 @@ func getStatus(ctx context.Context, log logr.Logger, actualAlert *alerts.Alert,
 
 	status.AlertType = flattenAlertType(actualAlert)
 
-	if notificationGroups, flattenErr := flattenNotificationGroups(ctx, log, actualAlert.GetNotificationGroups()); flattenErr != nil {
+	if notificationGroups, flattenErr := flattenNotificationGroups(ctx, actualAlert.GetNotificationGroups(), webhooks); flattenErr != nil {
 		err = stdErr.Join(err, fmt.Errorf("error on flatten alert - %w", flattenErr))
 	} else {
 		status.NotificationGroups = notificationGroups
@@ -979,51 +1188,50 @@ func flattenDaysOfWeek(daysOfWeek []alerts.DayOfWeek, daysOffset int32) []coralo
 	return result
 }
 
-func flattenNotificationGroups(ctx context.Context, log logr.Logger, notificationGroups []*alerts.AlertNotificationGroups) ([]coralogixv1alpha1.NotificationGroup, error) {
-	result := make([]coralogixv1alpha1.NotificationGroup, 0, len(notificationGroups))
-	webhooksIdsToNames, err := getWebhooksIdsToNames(ctx, log)
-	if err != nil {
-		return nil, fmt.Errorf("error on get webhooks ids to names - %w", err)
+func flattenNotificationGroups(ctx context.Context, notificationGroups []*alerts.AlertNotificationGroups, webhooks coralogixv1alpha1.NotificationWebhooks) ([]coralogixv1alpha1.NotificationGroup, error) {
+	// The notifications to the outbound webhooks the spec refers to are flattened to the same references, the others to
+	// the names of the outbound webhooks.
+	webhooksIdsToRefs := make(map[uint32]coralogixv1alpha1.OutboundWebhookReference, len(webhooks.RefIDs))
+	for ref, id := range webhooks.RefIDs {
+		webhooksIdsToRefs[id] = ref
 	}
 
+	result := make([]coralogixv1alpha1.NotificationGroup, 0, len(notificationGroups))
 	for _, ng := range notificationGroups {
-		notificationGroup := flattenNotificationGroup(ng, webhooksIdsToNames)
+		notificationGroup, err := flattenNotificationGroup(ctx, ng, webhooks.Resolver, webhooksIdsToRefs)
+		if err != nil {
+			return nil, err
+		}
 		result = append(result, *notificationGroup)
 	}
 
-	return result, err
+	return result, nil
 }
 
-func getWebhooksIdsToNames(ctx context.Context, log logr.Logger) (map[uint32]string, error) {
-	log.V(1).Info("get all webhooks")
-	webhooks, err := coralogixv1alpha1.WebhooksClient.List(ctx, &cxsdk.ListAllOutgoingWebhooksRequest{})
+func flattenNotificationGroup(ctx context.Context, notificationGroup *alerts.AlertNotificationGroups, resolver coralogixv1alpha1.WebhookResolver, webhooksIdsToRefs map[uint32]coralogixv1alpha1.OutboundWebhookReference) (*coralogixv1alpha1.NotificationGroup, error) {
+	notifications, err := flattenNotifications(ctx, notificationGroup.Notifications, resolver, webhooksIdsToRefs)
 	if err != nil {
-		return nil, fmt.Errorf("error on get all webhooks - %w", err)
-	}
-	webhooksIdsToNames := make(map[uint32]string)
-	for _, webhook := range webhooks.Deployed {
-		webhooksIdsToNames[webhook.GetExternalId().GetValue()] = webhook.GetName().GetValue()
+		return nil, err
 	}
-	return webhooksIdsToNames, nil
-}
-
-func flattenNotificationGroup(notificationGroup *alerts.AlertNotificationGroups, webhooksIdsToNames map[uint32]string) *coralogixv1alpha1.NotificationGroup {
 	return &coralogixv1alpha1.NotificationGroup{
 		GroupByFields: utils.WrappedStringSliceToStringSlice(notificationGroup.GroupByFields),
-		Notifications: flattenNotifications(notificationGroup.Notifications, webhooksIdsToNames),
-	}
+		Notifications: notifications,
+	}, nil
 }
 
-func flattenNotifications(notifications []*alerts.AlertNotification, webhooksIdsToNames map[uint32]string) []coralogixv1alpha1.Notification {
+func flattenNotifications(ctx context.Context, notifications []*alerts.AlertNotification, resolver coralogixv1alpha1.WebhookResolver, webhooksIdsToRefs map[uint32]coralogixv1alpha1.OutboundWebhookReference) ([]coralogixv1alpha1.Notification, error) {
 	result := make([]coralogixv1alpha1.Notification, 0, len(notifications))
 	for _, notification := range notifications {
-		flattenedNotification := flattenNotification(notification, webhooksIdsToNames)
+		flattenedNotification, err := flattenNotification(ctx, notification, resolver, webhooksIdsToRefs)
+		if err != nil {
+			return nil, err
+		}
 		result = append(result, flattenedNotification)
 	}
-	return result
+	return result, nil
 }
 
-func flattenNotification(notification *alerts.AlertNotification, webhooksIdsToNames map[uint32]string) coralogixv1alpha1.Notification {
+func flattenNotification(ctx context.Context, notification *alerts.AlertNotification, resolver coralogixv1alpha1.WebhookResolver, webhooksIdsToRefs map[uint32]coralogixv1alpha1.OutboundWebhookReference) (coralogixv1alpha1.Notification, error) {
 	notifyOn := alertProtoNotifyOn[notification.GetNotifyOn()]
 	retriggeringPeriodMinutes := int32(notification.GetRetriggeringPeriodSeconds().GetValue()) / 60
 	flattenedNotification := coralogixv1alpha1.Notification{
@@ -1033,13 +1241,26 @@ func flattenNotification(notification *alerts.AlertNotification, webhooksIdsToNa
 
 	switch integration := notification.GetIntegrationType().(type) {
 	case *alerts.AlertNotification_IntegrationId:
-		webhookName, _ := webhooksIdsToNames[integration.IntegrationId.GetValue()]
+		if ref, ok := webhooksIdsToRefs[integration.IntegrationId.GetValue()]; ok {
+			flattenedNotification.IntegrationRef = &ref
+			break
+		}
+		if resolver == nil {
+			return coralogixv1alpha1.Notification{}, fmt.Errorf("outbound webhook %d can't be resolved without a Coralogix account", integration.IntegrationId.GetValue())
+		}
+		webhookName, found, err := resolver.WebhookName(ctx, integration.IntegrationId.GetValue())
+		if err != nil {
+			return coralogixv1alpha1.Notification{}, fmt.Errorf("error on get webhooks ids to names - %w", err)
+		}
+		if !found {
+			return coralogixv1alpha1.Notification{}, &coralogixv1alpha1.UnresolvedWebhookError{ID: integration.IntegrationId.GetValue()}
+		}
 		flattenedNotification.IntegrationName = pointer.String(webhookName)
 	case *alerts.AlertNotification_Recipients:
 		flattenedNotification.EmailRecipients = utils.WrappedStringSliceToStringSlice(integration.Recipients.Emails)
 	}
 
-	return flattenedNotification
+	return flattenedNotification, nil
 }
 
 func flattenShowInInsight(showInInsight *alerts.ShowInInsight) *coralogixv1alpha1.ShowInInsight {
@@ -1056,9 +1277,57 @@ func flattenShowInInsight(showInInsight *alerts.ShowInInsight) *coralogixv1alpha
 	}
 }
 
+// externalIDChangedPredicate passes the outbound webhooks that were created in Coralogix, recreated or deleted, which
+// changes the external ID notified by the alerts referring to them.
+var externalIDChangedPredicate = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldWebhook, ok := e.ObjectOld.(*coralogixv1alpha1.OutboundWebhook)
+		if !ok {
+			return false
+		}
+		newWebhook, ok := e.ObjectNew.(*coralogixv1alpha1.OutboundWebhook)
+		if !ok {
+			return false
+		}
+		return ptr.Deref(oldWebhook.Status.ExternalID, "") != ptr.Deref(newWebhook.Status.ExternalID, "")
+	},
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// referringAlerts returns the requests of the alerts with notifications referring to an outbound webhook, to enqueue
+// them so they notify its current external ID.
+func (r *AlertReconciler) referringAlerts(ctx context.Context, webhook client.Object) []reconcile.Request {
+	var alertList coralogixv1alpha1.AlertList
+	if err := r.List(ctx, &alertList); err != nil {
+		log.FromContext(ctx).Error(err, "Error on listing alerts referring to outbound webhook",
+			"outboundWebhook", client.ObjectKeyFromObject(webhook))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, alert := range alertList.Items {
+		if !r.WatchFilter.Matches(&alert) {
+			continue
+		}
+		for _, ref := range alert.Spec.IntegrationRefs() {
+			if ref.ObjectKey(alert.Namespace) == client.ObjectKeyFromObject(webhook) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&alert)})
+				break
+			}
+		}
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *AlertReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&coralogixv1alpha1.Alert{}).
+		For(&coralogixv1alpha1.Alert{}, builder.WithPredicates(reconcilePredicate, r.WatchFilter.Predicate())).
+		Watches(&corev1.Namespace{}, enqueueNamespaceObjects(mgr.GetClient(), func() client.ObjectList { return &coralogixv1alpha1.AlertList{} }),
+			builder.WithPredicates(namespaceLabelChangedPredicate, r.WatchFilter.NamespacePredicate())).
+		Watches(&coralogixv1alpha1.OutboundWebhook{}, handler.EnqueueRequestsFromMapFunc(r.referringAlerts),
+			builder.WithPredicates(externalIDChangedPredicate)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
@@ -3,27 +3,34 @@ package alphacontrollers
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
 
 	utils "github.com/coralogix/coralogix-operator/apis"
 	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
 	alerts "github.com/coralogix/coralogix-operator/controllers/clientset/grpc/alerts/v2"
 	"github.com/coralogix/coralogix-operator/controllers/mock_clientset"
 )
@@ -50,6 +57,7 @@ func setupReconciler(t *testing.T, ctx context.Context, clientSet *mock_clientse
 	r := AlertReconciler{
 		Client:             withWatch,
 		Scheme:             mgr.GetScheme(),
+		Recorder:           mgr.GetEventRecorderFor("coralogix-operator"),
 		CoralogixClientSet: clientSet,
 	}
 	r.SetupWithManager(mgr)
@@ -366,9 +374,8 @@ func TestAlertUpdate(t *testing.T) {
 					Return(&alerts.GetAlertByUniqueIdResponse{Alert: params.remoteAlert}, nil).
 					MinTimes(1).MaxTimes(1)
 
-				params.webhooksClient.EXPECT().List(params.ctx, gomock.Any()).
-					Return(&cxsdk.ListAllOutgoingWebhooksResponse{}, nil).
-					MinTimes(1).MaxTimes(2)
+				// The notifications only have email recipients, so the outbound webhooks are never listed.
+				params.webhooksClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
 
 				params.alertsClient.EXPECT().CreateAlert(params.ctx, gomock.Any()).
 					Return(&alerts.CreateAlertResponse{Alert: params.remoteAlert}, nil).
@@ -379,13 +386,10 @@ func TestAlertUpdate(t *testing.T) {
 					Return(&alerts.GetAlertByUniqueIdResponse{Alert: params.remoteAlert}, nil).
 					MinTimes(1).MaxTimes(1)
 
-				params.webhooksClient.EXPECT().List(params.ctx, gomock.Any()).
-					Return(&cxsdk.ListAllOutgoingWebhooksResponse{}, nil).
-					MinTimes(1).MaxTimes(2)
-
+				// The remote alert has a label the spec doesn't, so it differs from the spec and is updated.
 				params.alertsClient.EXPECT().UpdateAlert(params.ctx, gomock.Any()).
 					Return(&alerts.UpdateAlertByUniqueIdResponse{Alert: params.remoteAlert}, nil).
-					MinTimes(1).MaxTimes(1)
+					Times(1)
 			},
 		},
 		{
@@ -434,18 +438,13 @@ func TestAlertUpdate(t *testing.T) {
 					Return(&alerts.GetAlertByUniqueIdResponse{Alert: params.remoteAlert}, nil).
 					MinTimes(1).MaxTimes(1)
 
-				params.webhooksClient.EXPECT().List(gomock.Any(), gomock.Any()).
-					Return(&cxsdk.ListAllOutgoingWebhooksResponse{}, nil).
-					MinTimes(1).MaxTimes(2)
+				// The notifications only have email recipients, so the outbound webhooks are never listed.
+				params.webhooksClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
 
 				params.alertsClient.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).
 					Return(&alerts.CreateAlertResponse{Alert: params.remoteAlert}, nil).
 					MinTimes(1).MaxTimes(1)
 
-				params.webhooksClient.EXPECT().List(gomock.Any(), gomock.Any()).
-					Return(&cxsdk.ListAllOutgoingWebhooksResponse{}, nil).
-					MinTimes(1).MaxTimes(2)
-
 				params.alertsClient.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).
 					Return(nil, status.Error(codes.NotFound, "")).
 					MinTimes(1).MaxTimes(1)
@@ -530,6 +529,252 @@ func TestAlertUpdate(t *testing.T) {
 
 }
 
+// newPromqlAlert returns a defaulted promql alert notifying notification, bound to the remote alert alert-id.
+func newPromqlAlert(notification coralogixv1alpha1.Notification) *coralogixv1alpha1.Alert {
+	alert := &coralogixv1alpha1.Alert{
+		ObjectMeta: metav1.ObjectMeta{Name: "alert", Namespace: "default"},
+		Spec: coralogixv1alpha1.AlertSpec{
+			Name:               "Alert",
+			Description:        "Alert",
+			Active:             true,
+			Severity:           coralogixv1alpha1.AlertSeverityCritical,
+			NotificationGroups: []coralogixv1alpha1.NotificationGroup{{Notifications: []coralogixv1alpha1.Notification{notification}}},
+			AlertType: coralogixv1alpha1.AlertType{
+				Metric: &coralogixv1alpha1.Metric{
+					Promql: &coralogixv1alpha1.Promql{
+						SearchQuery: "http_requests_total",
+						Conditions: coralogixv1alpha1.PromqlConditions{
+							AlertWhen:                  "MoreThanUsual",
+							Threshold:                  utils.FloatToQuantity(3.0),
+							TimeWindow:                 "TwelveHours",
+							MinNonNullValuesPercentage: pointer.Int(10),
+						},
+					},
+				},
+			},
+		},
+		Status: coralogixv1alpha1.AlertStatus{ID: pointer.String("alert-id")},
+	}
+	alert.Spec.Default()
+	return alert
+}
+
+// newRemotePromqlAlert returns the remote alert of newPromqlAlert, with description and notification.
+func newRemotePromqlAlert(description string, notification *alerts.AlertNotification) *alerts.Alert {
+	return &alerts.Alert{
+		UniqueIdentifier: wrapperspb.String("alert-id"),
+		Name:             wrapperspb.String("Alert"),
+		Description:      wrapperspb.String(description),
+		IsActive:         wrapperspb.Bool(true),
+		Severity:         alerts.AlertSeverity_ALERT_SEVERITY_CRITICAL,
+		MetaLabels: []*alerts.MetaLabel{
+			{Key: wrapperspb.String(coralogixv1alpha1.ManagedByLabelKey), Value: wrapperspb.String(coralogixv1alpha1.ManagedByLabelValue)},
+		},
+		Condition: &alerts.AlertCondition{
+			Condition: &alerts.AlertCondition_MoreThanUsual{
+				MoreThanUsual: &alerts.MoreThanUsualCondition{
+					Parameters: &alerts.ConditionParameters{
+						Threshold: wrapperspb.Double(3),
+						Timeframe: alerts.Timeframe_TIMEFRAME_12_H,
+						MetricAlertPromqlParameters: &alerts.MetricAlertPromqlConditionParameters{
+							PromqlText:        wrapperspb.String("http_requests_total"),
+							NonNullPercentage: wrapperspb.UInt32(10),
+							SwapNullValues:    wrapperspb.Bool(false),
+						},
+						NotifyGroupByOnlyAlerts: wrapperspb.Bool(false),
+					},
+				},
+			},
+		},
+		NotificationGroups: []*alerts.AlertNotificationGroups{{Notifications: []*alerts.AlertNotification{notification}}},
+		Filters:            &alerts.AlertFilters{FilterType: alerts.AlertFilters_FILTER_TYPE_METRIC},
+	}
+}
+
+func TestAlertUpdateOnlyWhenDifferent(t *testing.T) {
+	notification := coralogixv1alpha1.Notification{
+		RetriggeringPeriodMinutes: 10,
+		NotifyOn:                  coralogixv1alpha1.NotifyOnTriggeredAndResolved,
+		EmailRecipients:           []string{"example@coralogix.com"},
+	}
+	remoteAlert := func(description string) *alerts.Alert {
+		return newRemotePromqlAlert(description, &alerts.AlertNotification{
+			RetriggeringPeriodSeconds: wrapperspb.UInt32(600),
+			NotifyOn:                  alerts.NotifyOn_TRIGGERED_AND_RESOLVED.Enum(),
+			IntegrationType: &alerts.AlertNotification_Recipients{
+				Recipients: &alerts.Recipients{
+					Emails: []*wrapperspb.StringValue{wrapperspb.String("example@coralogix.com")},
+				},
+			},
+		})
+	}
+
+	tests := []struct {
+		name        string
+		remoteAlert *alerts.Alert
+		updates     int
+	}{
+		{name: "remote alert equal to the spec", remoteAlert: remoteAlert("Alert"), updates: 0},
+		{name: "remote alert different from the spec", remoteAlert: remoteAlert("Changed"), updates: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			ctx := context.Background()
+
+			alertsClient := mock_clientset.NewMockAlertsClientInterface(controller)
+			alertsClient.EXPECT().GetAlert(ctx, gomock.Any()).
+				Return(&alerts.GetAlertByUniqueIdResponse{Alert: tt.remoteAlert}, nil).Times(1)
+			alertsClient.EXPECT().UpdateAlert(ctx, gomock.Any()).
+				Return(&alerts.UpdateAlertByUniqueIdResponse{Alert: remoteAlert("Alert")}, nil).Times(tt.updates)
+			clientSet := mock_clientset.NewMockClientSetInterface(controller)
+			clientSet.EXPECT().Alerts().Return(alertsClient).AnyTimes()
+
+			alert := newPromqlAlert(notification)
+			scheme := runtime.NewScheme()
+			utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+			reconciler := AlertReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(alert).WithStatusSubresource(alert).Build(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			err := reconciler.update(ctx, logr.Discard(), clientSet, coralogixv1alpha1.NotificationWebhooks{}, alert)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAlertCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+
+	notification := &alerts.AlertNotification{
+		RetriggeringPeriodSeconds: wrapperspb.UInt32(600),
+		NotifyOn:                  alerts.NotifyOn_TRIGGERED_AND_RESOLVED.Enum(),
+		IntegrationType: &alerts.AlertNotification_Recipients{
+			Recipients: &alerts.Recipients{Emails: []*wrapperspb.StringValue{wrapperspb.String("example@coralogix.com")}},
+		},
+	}
+	alertsClient := mock_clientset.NewMockAlertsClientInterface(controller)
+	alertsClient.EXPECT().CreateAlert(ctx, gomock.Any()).
+		Return(&alerts.CreateAlertResponse{Alert: newRemotePromqlAlert("Alert", notification)}, nil).Times(1)
+	clientSet := mock_clientset.NewMockClientSetInterface(controller)
+	clientSet.EXPECT().Alerts().Return(alertsClient).AnyTimes()
+
+	alert := newPromqlAlert(coralogixv1alpha1.Notification{
+		RetriggeringPeriodMinutes: 10,
+		NotifyOn:                  coralogixv1alpha1.NotifyOnTriggeredAndResolved,
+		EmailRecipients:           []string{"example@coralogix.com"},
+	})
+	alert.Status = coralogixv1alpha1.AlertStatus{}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(alert).WithStatusSubresource(alert).Build()
+	reconciler := AlertReconciler{Client: c, Recorder: record.NewFakeRecorder(10)}
+
+	assert.NoError(t, reconciler.create(ctx, logr.Discard(), clientSet, coralogixv1alpha1.NotificationWebhooks{}, alert))
+
+	// The ID is written with the status, and the finalizer is patched on its own.
+	latest := &coralogixv1alpha1.Alert{}
+	assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(alert), latest))
+	assert.Equal(t, pointer.String("alert-id"), latest.Status.ID)
+	assert.Equal(t, []string{alertFinalizerName}, latest.Finalizers)
+}
+
+func TestAlertWebhookResolversCache(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+
+	remoteAlert := newRemotePromqlAlert("Alert", &alerts.AlertNotification{
+		RetriggeringPeriodSeconds: wrapperspb.UInt32(600),
+		NotifyOn:                  alerts.NotifyOn_TRIGGERED_AND_RESOLVED.Enum(),
+		IntegrationType:           &alerts.AlertNotification_IntegrationId{IntegrationId: wrapperspb.UInt32(1)},
+	})
+	alertsClient := mock_clientset.NewMockAlertsClientInterface(controller)
+	alertsClient.EXPECT().GetAlert(ctx, gomock.Any()).
+		Return(&alerts.GetAlertByUniqueIdResponse{Alert: remoteAlert}, nil).Times(2)
+	alertsClient.EXPECT().UpdateAlert(ctx, gomock.Any()).Times(0)
+
+	// The summaries of the webhooks aren't exported by the SDK, so the response is built from its JSON.
+	webhooksResponse := &cxsdk.ListAllOutgoingWebhooksResponse{}
+	assert.NoError(t, protojson.Unmarshal([]byte(`{"deployed": [{"name": "slack", "externalId": 1}]}`), webhooksResponse))
+	webhooksClient := mock_clientset.NewMockOutboundWebhooksClientInterface(controller)
+	// Within the TTL of the resolvers, the webhooks are listed once for all reconciliations.
+	webhooksClient.EXPECT().List(ctx, gomock.Any()).Return(webhooksResponse, nil).Times(1)
+
+	clientSet := mock_clientset.NewMockClientSetInterface(controller)
+	clientSet.EXPECT().Alerts().Return(alertsClient).AnyTimes()
+	clientSet.EXPECT().OutboundWebhooks().Return(webhooksClient).AnyTimes()
+
+	alert := newPromqlAlert(coralogixv1alpha1.Notification{
+		RetriggeringPeriodMinutes: 10,
+		NotifyOn:                  coralogixv1alpha1.NotifyOnTriggeredAndResolved,
+		IntegrationName:           pointer.String("slack"),
+	})
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+	reconciler := AlertReconciler{
+		Client:           fake.NewClientBuilder().WithScheme(scheme).WithObjects(alert).WithStatusSubresource(alert).Build(),
+		Recorder:         record.NewFakeRecorder(10),
+		WebhookResolvers: clientset.NewWebhookResolvers(time.Minute),
+	}
+
+	for i := 0; i < 2; i++ {
+		webhooks := coralogixv1alpha1.NotificationWebhooks{Resolver: reconciler.webhookResolver(clientSet)}
+		assert.NoError(t, reconciler.update(ctx, logr.Discard(), clientSet, webhooks, alert))
+	}
+}
+
+func TestAlertUpdateUnresolvedWebhook(t *testing.T) {
+	controller := gomock.NewController(t)
+	ctx := context.Background()
+
+	remoteAlert := func(integrationID uint32) *alerts.Alert {
+		return newRemotePromqlAlert("Alert", &alerts.AlertNotification{
+			RetriggeringPeriodSeconds: wrapperspb.UInt32(600),
+			NotifyOn:                  alerts.NotifyOn_TRIGGERED_AND_RESOLVED.Enum(),
+			IntegrationType:           &alerts.AlertNotification_IntegrationId{IntegrationId: wrapperspb.UInt32(integrationID)},
+		})
+	}
+	// The remote alert notifies a webhook that was deleted, so it is updated to notify the one of the spec.
+	alertsClient := mock_clientset.NewMockAlertsClientInterface(controller)
+	alertsClient.EXPECT().GetAlert(ctx, gomock.Any()).
+		Return(&alerts.GetAlertByUniqueIdResponse{Alert: remoteAlert(2)}, nil).Times(1)
+	alertsClient.EXPECT().UpdateAlert(ctx, gomock.Any()).
+		Return(&alerts.UpdateAlertByUniqueIdResponse{Alert: remoteAlert(1)}, nil).Times(1)
+
+	webhooksResponse := &cxsdk.ListAllOutgoingWebhooksResponse{}
+	assert.NoError(t, protojson.Unmarshal([]byte(`{"deployed": [{"name": "slack", "externalId": 1}]}`), webhooksResponse))
+	webhooksClient := mock_clientset.NewMockOutboundWebhooksClientInterface(controller)
+	webhooksClient.EXPECT().List(ctx, gomock.Any()).Return(webhooksResponse, nil).AnyTimes()
+
+	clientSet := mock_clientset.NewMockClientSetInterface(controller)
+	clientSet.EXPECT().Alerts().Return(alertsClient).AnyTimes()
+	webhooks := coralogixv1alpha1.NotificationWebhooks{Resolver: clientset.NewWebhookResolver(webhooksClient, time.Minute)}
+
+	_, err := getStatus(ctx, remoteAlert(2), coralogixv1alpha1.AlertSpec{}, webhooks)
+	var unresolvedWebhook *coralogixv1alpha1.UnresolvedWebhookError
+	if assert.ErrorAs(t, err, &unresolvedWebhook) {
+		assert.Equal(t, uint32(2), unresolvedWebhook.ID)
+	}
+
+	alert := newPromqlAlert(coralogixv1alpha1.Notification{
+		RetriggeringPeriodMinutes: 10,
+		NotifyOn:                  coralogixv1alpha1.NotifyOnTriggeredAndResolved,
+		IntegrationName:           pointer.String("slack"),
+	})
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+	reconciler := AlertReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(alert).WithStatusSubresource(alert).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	assert.NoError(t, reconciler.update(ctx, logr.Discard(), clientSet, webhooks, alert))
+	assert.Equal(t, "Notifications.IntegrationId", alert.Status.LastDrift.Field)
+}
+
 func TestAlertDelete(t *testing.T) {
 	defaultNotificationGroups := []coralogixv1alpha1.NotificationGroup{
 		{
@@ -648,9 +893,8 @@ func TestAlertDelete(t *testing.T) {
 					Return(&alerts.GetAlertByUniqueIdResponse{Alert: params.remoteAlert}, nil).
 					MinTimes(1).MaxTimes(1)
 
-				params.webhooksClient.EXPECT().List(params.ctx, gomock.Any()).
-					Return(&cxsdk.ListAllOutgoingWebhooksResponse{}, nil).
-					MinTimes(1).MaxTimes(2)
+				// The notifications only have email recipients, so the outbound webhooks are never listed.
+				params.webhooksClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
 
 				params.alertsClient.EXPECT().CreateAlert(params.ctx, gomock.Any()).
 					Return(&alerts.CreateAlertResponse{Alert: params.remoteAlert}, nil).
@@ -780,16 +1024,15 @@ func TestFlattenAlerts(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	log := log.FromContext(ctx)
 
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 
 	webhookMock := mock_clientset.NewMockOutboundWebhooksClientInterface(controller)
 	webhookMock.EXPECT().List(ctx, gomock.Any()).Return(&cxsdk.ListAllOutgoingWebhooksResponse{}, nil).AnyTimes()
-	coralogixv1alpha1.WebhooksClient = webhookMock
+	webhooks := coralogixv1alpha1.NotificationWebhooks{Resolver: clientset.NewWebhookResolver(webhookMock, 0)}
 
-	status, err := getStatus(ctx, log, alert, spec)
+	status, err := getStatus(ctx, alert, spec, webhooks)
 	assert.NoError(t, err)
 
 	expected := &coralogixv1alpha1.AlertStatus{
@@ -819,3 +1062,44 @@ func TestFlattenAlerts(t *testing.T) {
 
 	assert.EqualValues(t, expected, &status)
 }
+
+func TestAlertIntegrationRefs(t *testing.T) {
+	ref := coralogixv1alpha1.OutboundWebhookReference{Name: "slack"}
+	webhooks := coralogixv1alpha1.NotificationWebhooks{RefIDs: map[coralogixv1alpha1.OutboundWebhookReference]uint32{ref: 7}}
+
+	// The notifications to referenced webhooks are flattened without looking up the webhooks in Coralogix.
+	notificationGroups, err := flattenNotificationGroups(context.Background(), []*alerts.AlertNotificationGroups{
+		{Notifications: []*alerts.AlertNotification{
+			{IntegrationType: &alerts.AlertNotification_IntegrationId{IntegrationId: wrapperspb.UInt32(7)}},
+		}},
+	}, webhooks)
+	assert.NoError(t, err)
+	assert.Equal(t, &ref, notificationGroups[0].Notifications[0].IntegrationRef)
+	assert.Nil(t, notificationGroups[0].Notifications[0].IntegrationName)
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+	referring := &coralogixv1alpha1.Alert{
+		ObjectMeta: metav1.ObjectMeta{Name: "referring", Namespace: "monitoring"},
+		Spec: coralogixv1alpha1.AlertSpec{NotificationGroups: []coralogixv1alpha1.NotificationGroup{
+			{Notifications: []coralogixv1alpha1.Notification{{IntegrationRef: &ref}}},
+		}},
+	}
+	other := &coralogixv1alpha1.Alert{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		Spec: coralogixv1alpha1.AlertSpec{NotificationGroups: []coralogixv1alpha1.NotificationGroup{
+			{Notifications: []coralogixv1alpha1.Notification{{IntegrationRef: &ref}}},
+		}},
+	}
+	reconciler := AlertReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(referring, other).Build()}
+
+	webhook := &coralogixv1alpha1.OutboundWebhook{ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "monitoring"}}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "referring", Namespace: "monitoring"}}},
+		reconciler.referringAlerts(context.Background(), webhook))
+
+	recreated := webhook.DeepCopy()
+	recreated.Status.ExternalID = pointer.String("8")
+	assert.True(t, externalIDChangedPredicate.Update(event.UpdateEvent{ObjectOld: webhook, ObjectNew: recreated}))
+	assert.False(t, externalIDChangedPredicate.Update(event.UpdateEvent{ObjectOld: recreated, ObjectNew: recreated.DeepCopy()}))
+	assert.True(t, externalIDChangedPredicate.Delete(event.DeleteEvent{Object: recreated}))
+}
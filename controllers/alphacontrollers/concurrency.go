@@ -0,0 +1,47 @@
+package alphacontrollers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/coralogix/coralogix-operator/controllers/overrides"
+)
+
+// ConcurrencyKinds are the kinds whose controllers can reconcile several resources in parallel.
+var ConcurrencyKinds = []string{"Alert", "RuleGroup", "RecordingRuleGroupSet", "OutboundWebhook", "PrometheusRule", "AlertmanagerConfig"}
+
+// MaxConcurrentReconciles holds the number of resources of each kind that are reconciled in parallel.
+type MaxConcurrentReconciles struct {
+	Default int
+	ByKind  map[string]int
+}
+
+// For returns the maximum concurrent reconciles of kind, falling back to the default one.
+// Zero leaves the default of controller-runtime, which is one.
+func (m MaxConcurrentReconciles) For(kind string) int {
+	if value, ok := m.ByKind[kind]; ok {
+		return value
+	}
+	return m.Default
+}
+
+// ParseMaxConcurrentReconciles parses a comma separated list of a default number and <Kind>=<number> overrides,
+// e.g. "2,Alert=4".
+func ParseMaxConcurrentReconciles(value string) (MaxConcurrentReconciles, error) {
+	defaultReconciles, byKind, err := overrides.Parse(value, "max concurrent reconciles", "kind", ConcurrencyKinds, parseMaxConcurrentReconciles)
+	if err != nil {
+		return MaxConcurrentReconciles{}, err
+	}
+	return MaxConcurrentReconciles{Default: defaultReconciles, ByKind: byKind}, nil
+}
+
+func parseMaxConcurrentReconciles(value string) (int, error) {
+	reconciles, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if reconciles < 1 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return reconciles, nil
+}
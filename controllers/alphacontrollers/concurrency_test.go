@@ -0,0 +1,51 @@
+package alphacontrollers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMaxConcurrentReconciles(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		want       map[string]int
+		shouldFail bool
+	}{
+		{
+			name:  "empty value keeps the default of controller-runtime",
+			value: "",
+			want:  map[string]int{"Alert": 0, "PrometheusRule": 0},
+		},
+		{
+			name:  "overrides per kind",
+			value: "2, Alert=4,PrometheusRule=1",
+			want:  map[string]int{"Alert": 4, "PrometheusRule": 1, "RuleGroup": 2, "AlertmanagerConfig": 2},
+		},
+		{
+			name:       "unknown kind",
+			value:      "Dashboard=2",
+			shouldFail: true,
+		},
+		{
+			name:       "zero",
+			value:      "0",
+			shouldFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			concurrency, err := ParseMaxConcurrentReconciles(tt.value)
+			if tt.shouldFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			for kind, want := range tt.want {
+				assert.Equal(t, want, concurrency.For(kind), kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package alphacontrollers
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+// recordPlan records the change the reconciler would make to the remote object of obj in dry-run mode, instead of
+// making it. The plan is written to the status by updateSyncStatus.
+// field is the first field that differs from the spec for updates, and request is the request that would be sent, which
+// is recorded with its sensitive fields redacted.
+func recordPlan(recorder record.EventRecorder, obj syncStatusObject, kind string, action coralogixv1alpha1.PlanAction, field string, request proto.Message) {
+	var requestJSON string
+	if request != nil {
+		requestJSON = redactedJSON(request)
+	}
+	obj.GetSyncStatus().Plan = coralogixv1alpha1.NewPlan(action, field, requestJSON)
+
+	message := "Dry run: remote " + kind + " would be " + planActionPastTense(action)
+	if field != "" {
+		message += ", it differs from the spec at " + field
+	}
+	recorder.Event(obj, corev1.EventTypeNormal, ReasonPlanned, message)
+}
+
+// planActionPastTense returns action as a past participle, e.g. created.
+func planActionPastTense(action coralogixv1alpha1.PlanAction) string {
+	return strings.ToLower(string(action)) + "d"
+}
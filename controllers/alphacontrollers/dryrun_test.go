@@ -0,0 +1,53 @@
+package alphacontrollers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+func TestRecordPlan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+
+	ctx := context.Background()
+	ruleGroup := &coralogixv1alpha1.RuleGroup{ObjectMeta: metav1.ObjectMeta{Name: "rule-group", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ruleGroup).WithStatusSubresource(ruleGroup).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	recordPlan(recorder, ruleGroup, "rule group", coralogixv1alpha1.PlanActionUpdate, "Severities",
+		&cxsdk.UpdateRuleGroupRequest{GroupId: wrapperspb.String("id")})
+	assert.Equal(t, "Normal Planned Dry run: remote rule group would be updated, it differs from the spec at Severities", <-recorder.Events)
+
+	assert.NoError(t, updateSyncStatus(ctx, c, ruleGroup, true, nil))
+	latest := &coralogixv1alpha1.RuleGroup{}
+	assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(ruleGroup), latest))
+	if assert.NotNil(t, latest.Status.Plan) {
+		assert.Equal(t, coralogixv1alpha1.PlanActionUpdate, latest.Status.Plan.Action)
+		assert.Equal(t, "Severities", latest.Status.Plan.Field)
+		assert.Contains(t, latest.Status.Plan.Request, `"groupId"`)
+	}
+	synced := meta.FindStatusCondition(latest.Status.Conditions, coralogixv1alpha1.ConditionTypeSynced)
+	if assert.NotNil(t, synced) {
+		assert.Equal(t, coralogixv1alpha1.ReasonPlanned, synced.Reason)
+	}
+
+	// Without dry-run, the plan is cleared.
+	assert.NoError(t, updateSyncStatus(ctx, c, latest, false, nil))
+	assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(ruleGroup), latest))
+	assert.Nil(t, latest.Status.Plan)
+	assert.True(t, meta.IsStatusConditionTrue(latest.Status.Conditions, coralogixv1alpha1.ConditionTypeSynced))
+}
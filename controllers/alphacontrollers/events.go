@@ -0,0 +1,63 @@
+package alphacontrollers
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+// Reasons of the events recorded on the resources.
+const (
+	ReasonCreated            = "Created"
+	ReasonImported           = "Imported"
+	ReasonImportConflict     = "ImportConflict"
+	ReasonUpdated            = "Updated"
+	ReasonDeleted            = "Deleted"
+	ReasonRetained           = "Retained"
+	ReasonRemoteNotFound     = "RemoteNotFound"
+	ReasonAccountNotFound    = "AccountNotFound"
+	ReasonDriftDetected      = "DriftDetected"
+	ReasonPaused             = "Paused"
+	ReasonPlanned            = "Planned"
+	ReasonValidationFailed   = "ValidationFailed"
+	ReasonWebhookNotResolved = "WebhookNotResolved"
+	ReasonTestSucceeded      = "TestSucceeded"
+	ReasonTestFailed         = "TestFailed"
+)
+
+// recordDrift records a drift of the remote object from the spec, and whether it was overwritten.
+func recordDrift(recorder record.EventRecorder, obj runtime.Object, kind string, drift *coralogixv1alpha1.Drift) {
+	if drift.Enforced {
+		recorder.Eventf(obj, corev1.EventTypeWarning, ReasonDriftDetected,
+			"Remote %s was changed outside of the operator at %s, overwriting it with the spec", kind, drift.Field)
+		return
+	}
+	recorder.Eventf(obj, corev1.EventTypeWarning, ReasonDriftDetected,
+		"Remote %s was changed outside of the operator at %s, leaving it as is since the drift policy is Report", kind, drift.Field)
+}
+
+// recordInvalidSpec records that the spec could not be converted to a request because of err.
+// Errors of the Coralogix API, e.g. when listing the webhooks to resolve, are reported in the conditions only.
+func recordInvalidSpec(recorder record.EventRecorder, obj runtime.Object, kind string, err error) {
+	if _, ok := status.FromError(err); ok {
+		return
+	}
+
+	var unresolvedWebhook *coralogixv1alpha1.UnresolvedWebhookError
+	var notReadyWebhook *coralogixv1alpha1.OutboundWebhookNotReadyError
+	var importConflict *coralogixv1alpha1.ImportConflictError
+	if errors.As(err, &unresolvedWebhook) {
+		recorder.Event(obj, corev1.EventTypeWarning, ReasonWebhookNotResolved, unresolvedWebhook.Error())
+	} else if errors.As(err, &notReadyWebhook) {
+		recorder.Event(obj, corev1.EventTypeWarning, ReasonWebhookNotResolved, notReadyWebhook.Error())
+	} else if errors.As(err, &importConflict) {
+		recorder.Event(obj, corev1.EventTypeWarning, ReasonImportConflict, importConflict.Error())
+	} else {
+		recorder.Eventf(obj, corev1.EventTypeWarning, ReasonValidationFailed, "Invalid %s spec: %s", kind, err)
+	}
+}
@@ -0,0 +1,384 @@
+package alphacontrollers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
+	alerts "github.com/coralogix/coralogix-operator/controllers/clientset/grpc/alerts/v2"
+)
+
+// ExportKinds are the kinds of remote objects Exporter writes manifests for.
+var ExportKinds = []string{"alerts", "rulegroups", "webhooks", "recordingrules"}
+
+// maxExportNameLength keeps the generated names valid as label values too.
+const maxExportNameLength = 63
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ParseExportKinds parses a comma separated list of ExportKinds. An empty list means all of them.
+func ParseExportKinds(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return ExportKinds, nil
+	}
+
+	var kinds []string
+	for _, kind := range strings.Split(value, ",") {
+		kind = strings.TrimSpace(kind)
+		if !isExportKind(kind) {
+			return nil, fmt.Errorf("invalid kind %q: should be one of %q", kind, ExportKinds)
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+func isExportKind(kind string) bool {
+	for _, exportKind := range ExportKinds {
+		if kind == exportKind {
+			return true
+		}
+	}
+	return false
+}
+
+// Exporter writes the remote objects of a Coralogix team as manifests of the resources managing them.
+// Each resource is annotated with the ID of its remote object, so applying it imports the object instead of
+// creating a copy.
+type Exporter struct {
+	ClientSet clientset.ClientSetInterface
+	// Namespace is the namespace of the resources. When empty, they are applied to the current namespace.
+	Namespace string
+	// Warnings receives the Secrets to create for the exported outbound webhooks and the skipped alerts, when set.
+	Warnings io.Writer
+
+	names map[string]map[string]bool
+}
+
+// Export writes the manifests of the remote objects of kinds to w, as a multi-document YAML.
+func (e *Exporter) Export(ctx context.Context, kinds []string, w io.Writer) error {
+	e.names = make(map[string]map[string]bool)
+	for _, kind := range kinds {
+		var objs []client.Object
+		var err error
+		switch kind {
+		case "alerts":
+			objs, err = e.exportAlerts(ctx)
+		case "rulegroups":
+			objs, err = e.exportRuleGroups(ctx)
+		case "webhooks":
+			objs, err = e.exportOutboundWebhooks(ctx)
+		case "recordingrules":
+			objs, err = e.exportRecordingRuleGroupSets(ctx)
+		default:
+			err = fmt.Errorf("should be one of %q", ExportKinds)
+		}
+		if err != nil {
+			return fmt.Errorf("error on exporting %s: %w", kind, err)
+		}
+
+		for _, obj := range objs {
+			if err = writeManifest(w, obj); err != nil {
+				return fmt.Errorf("error on writing %s %s: %w", kind, obj.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) exportAlerts(ctx context.Context) ([]client.Object, error) {
+	resp, err := e.ClientSet.Alerts().GetAlerts(ctx, &alerts.GetAlertsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	remoteAlerts := resp.GetAlerts()
+	sort.Slice(remoteAlerts, func(i, j int) bool {
+		return lessByNameAndID(remoteAlerts[i].GetName().GetValue(), remoteAlerts[i].GetUniqueIdentifier().GetValue(),
+			remoteAlerts[j].GetName().GetValue(), remoteAlerts[j].GetUniqueIdentifier().GetValue())
+	})
+
+	// The notifications refer to the outbound webhooks by their name, which is resolved by flattening them.
+	// The webhooks are listed once for all the exported alerts.
+	webhooks := coralogixv1alpha1.NotificationWebhooks{Resolver: clientset.NewWebhookResolver(e.ClientSet.OutboundWebhooks(), time.Hour)}
+	spec := coralogixv1alpha1.AlertSpec{Scheduling: &coralogixv1alpha1.Scheduling{TimeZone: coralogixv1alpha1.DefaultTimeZone}}
+	objs := make([]client.Object, 0, len(remoteAlerts))
+	for _, remoteAlert := range remoteAlerts {
+		status, err := getStatus(ctx, remoteAlert, spec, webhooks)
+		var unresolvedWebhook *coralogixv1alpha1.UnresolvedWebhookError
+		if errors.As(err, &unresolvedWebhook) {
+			// The spec can't refer to a deleted outbound webhook, the alert needs to be fixed in Coralogix first.
+			e.warnf("warning: skipping alert %s (%s), %s\n", remoteAlert.GetName().GetValue(), remoteAlert.GetUniqueIdentifier().GetValue(), err)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error on flattening alert %s: %w", remoteAlert.GetUniqueIdentifier().GetValue(), err)
+		}
+		// Alerts created by the operator already have a resource.
+		if status.Labels[coralogixv1alpha1.ManagedByLabelKey] == coralogixv1alpha1.ManagedByLabelValue {
+			continue
+		}
+
+		alert := &coralogixv1alpha1.Alert{
+			Spec: coralogixv1alpha1.AlertSpec{
+				Name:               status.Name,
+				Description:        status.Description,
+				Active:             status.Active,
+				Severity:           status.Severity,
+				Labels:             status.Labels,
+				ExpirationDate:     status.ExpirationDate,
+				NotificationGroups: status.NotificationGroups,
+				ShowInInsight:      status.ShowInInsight,
+				PayloadFilters:     status.PayloadFilters,
+				Scheduling:         status.Scheduling,
+				AlertType:          status.AlertType,
+			},
+		}
+		e.setMeta(alert, "Alert", status.Name, ptr.Deref(status.ID, ""))
+		objs = append(objs, alert)
+	}
+	return objs, nil
+}
+
+func (e *Exporter) exportRuleGroups(ctx context.Context) ([]client.Object, error) {
+	ruleGroups, err := e.ClientSet.RuleGroups().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ruleGroups, func(i, j int) bool {
+		return lessByNameAndID(ruleGroups[i].GetName().GetValue(), ruleGroups[i].GetId().GetValue(),
+			ruleGroups[j].GetName().GetValue(), ruleGroups[j].GetId().GetValue())
+	})
+
+	objs := make([]client.Object, 0, len(ruleGroups))
+	for _, remoteRuleGroup := range ruleGroups {
+		status, err := flattenRuleGroup(remoteRuleGroup)
+		if err != nil {
+			return nil, err
+		}
+		// The subgroups are matched by their order, their IDs belong to the remote rule group only.
+		for i := range status.RuleSubgroups {
+			status.RuleSubgroups[i].ID = nil
+		}
+
+		ruleGroup := &coralogixv1alpha1.RuleGroup{
+			Spec: coralogixv1alpha1.RuleGroupSpec{
+				Name:          status.Name,
+				Description:   status.Description,
+				Active:        status.Active,
+				Applications:  status.Applications,
+				Subsystems:    status.Subsystems,
+				Severities:    status.Severities,
+				Hidden:        status.Hidden,
+				Creator:       status.Creator,
+				Order:         status.Order,
+				RuleSubgroups: status.RuleSubgroups,
+			},
+		}
+		e.setMeta(ruleGroup, "RuleGroup", status.Name, ptr.Deref(status.ID, ""))
+		objs = append(objs, ruleGroup)
+	}
+	return objs, nil
+}
+
+func (e *Exporter) exportOutboundWebhooks(ctx context.Context) ([]client.Object, error) {
+	resp, err := e.ClientSet.OutboundWebhooks().List(ctx, &cxsdk.ListAllOutgoingWebhooksRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := resp.GetDeployed()
+	sort.Slice(summaries, func(i, j int) bool {
+		return lessByNameAndID(summaries[i].GetName().GetValue(), summaries[i].GetId().GetValue(),
+			summaries[j].GetName().GetValue(), summaries[j].GetId().GetValue())
+	})
+
+	objs := make([]client.Object, 0, len(summaries))
+	for _, summary := range summaries {
+		readResponse, err := e.ClientSet.OutboundWebhooks().Get(ctx, &cxsdk.GetOutgoingWebhookRequest{Id: wrapperspb.String(summary.GetId().GetValue())})
+		if err != nil {
+			return nil, fmt.Errorf("error on getting outbound-webhook %s: %w", summary.GetId().GetValue(), err)
+		}
+		status, err := getOutboundWebhookStatus(readResponse.GetWebhook())
+		if err != nil {
+			return nil, fmt.Errorf("error on flattening outbound-webhook %s: %w", summary.GetId().GetValue(), err)
+		}
+
+		webhook := &coralogixv1alpha1.OutboundWebhook{Spec: coralogixv1alpha1.OutboundWebhookSpec{Name: status.Name}}
+		e.setMeta(webhook, "OutboundWebhook", status.Name, ptr.Deref(status.ID, ""))
+		// The credentials are never written, the resource takes them from a Secret to create along with it.
+		secretName := webhook.Name + "-credentials"
+		webhookType := outboundWebhookTypeFromStatus(status.OutboundWebhookType)
+		referred, keys := webhookType.ReferSecret(secretName)
+		webhook.Spec.OutboundWebhookType = *referred
+		if len(keys) > 0 {
+			e.warnf("warning: OutboundWebhook %s takes its credentials from the Secret %s, which should be created with the keys %s\n",
+				webhook.Name, secretName, strings.Join(keys, ", "))
+		}
+		objs = append(objs, webhook)
+	}
+	return objs, nil
+}
+
+func (e *Exporter) exportRecordingRuleGroupSets(ctx context.Context) ([]client.Object, error) {
+	resp, err := e.ClientSet.RecordingRuleGroups().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sets := resp.GetSets()
+	sort.Slice(sets, func(i, j int) bool {
+		return lessByNameAndID(sets[i].GetName(), sets[i].GetId(), sets[j].GetName(), sets[j].GetId())
+	})
+
+	objs := make([]client.Object, 0, len(sets))
+	for _, set := range sets {
+		recordingRuleGroupSet := &coralogixv1alpha1.RecordingRuleGroupSet{
+			Spec: coralogixv1alpha1.RecordingRuleGroupSetSpec{
+				Groups: flattenRecordingRuleGroups(set.GetGroups()),
+			},
+		}
+		e.setMeta(recordingRuleGroupSet, "RecordingRuleGroupSet", set.GetName(), set.GetId())
+		objs = append(objs, recordingRuleGroupSet)
+	}
+	return objs, nil
+}
+
+// outboundWebhookTypeFromStatus returns the spec of a flattened outbound-webhook, without the fields set by Coralogix.
+func outboundWebhookTypeFromStatus(status *coralogixv1alpha1.OutboundWebhookTypeStatus) coralogixv1alpha1.OutboundWebhookType {
+	if status == nil {
+		return coralogixv1alpha1.OutboundWebhookType{}
+	}
+
+	webhookType := coralogixv1alpha1.OutboundWebhookType{
+		Slack:          status.Slack,
+		PagerDuty:      status.PagerDuty,
+		EmailGroup:     status.EmailGroup,
+		MicrosoftTeams: status.MicrosoftTeams,
+		Jira:           status.Jira,
+		Opsgenie:       status.Opsgenie,
+		Demisto:        status.Demisto,
+		AwsEventBridge: status.AwsEventBridge,
+	}
+	if generic := status.GenericWebhook; generic != nil {
+		webhookType.GenericWebhook = &coralogixv1alpha1.GenericWebhook{
+			Url:     generic.Url,
+			Method:  generic.Method,
+			Headers: generic.Headers,
+			Payload: generic.Payload,
+		}
+	}
+	if sendLog := status.SendLog; sendLog != nil {
+		webhookType.SendLog = &coralogixv1alpha1.SendLog{
+			Payload: sendLog.Payload,
+			Url:     sendLog.Url,
+		}
+	}
+	return webhookType
+}
+
+func (e *Exporter) warnf(format string, args ...interface{}) {
+	if e.Warnings != nil {
+		fmt.Fprintf(e.Warnings, format, args...)
+	}
+}
+
+// setMeta sets the type of obj, a name derived from the name of its remote object and unique among the
+// resources of the same kind, and the annotation importing the remote object id.
+func (e *Exporter) setMeta(obj client.Object, kind, remoteName, id string) {
+	obj.GetObjectKind().SetGroupVersionKind(coralogixv1alpha1.GroupVersion.WithKind(kind))
+	obj.SetNamespace(e.Namespace)
+	obj.SetAnnotations(map[string]string{coralogixv1alpha1.ImportIDAnnotation: id})
+
+	names := e.names[kind]
+	if names == nil {
+		names = make(map[string]bool)
+		e.names[kind] = names
+	}
+	base := exportName(remoteName)
+	if base == "" {
+		base = exportName(strings.ToLower(kind) + "-" + id)
+	}
+	name := base
+	for i := 2; names[name]; i++ {
+		suffix := "-" + strconv.Itoa(i)
+		name = strings.TrimRight(truncate(base, maxExportNameLength-len(suffix)), "-") + suffix
+	}
+	names[name] = true
+	obj.SetName(name)
+}
+
+// lessByNameAndID orders the remote objects, so the names of the resources are stable across exports.
+func lessByNameAndID(nameI, idI, nameJ, idJ string) bool {
+	if nameI != nameJ {
+		return nameI < nameJ
+	}
+	return idI < idJ
+}
+
+// exportName turns name into a valid resource name, e.g. "High 5xx Rate!" into "high-5xx-rate".
+func exportName(name string) string {
+	name = invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-")
+	return strings.Trim(truncate(strings.Trim(name, "-"), maxExportNameLength), "-")
+}
+
+func truncate(s string, length int) string {
+	if len(s) > length {
+		return s[:length]
+	}
+	return s
+}
+
+// writeManifest writes obj as a YAML document, without its status and the metadata set by the API server.
+func writeManifest(w io.Writer, obj client.Object) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	delete(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+	removeNulls(content)
+
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "---\n%s", data)
+	return err
+}
+
+// removeNulls removes the unset optional fields, which the converter keeps as nulls.
+func removeNulls(content map[string]interface{}) {
+	for key, value := range content {
+		switch value := value.(type) {
+		case nil:
+			delete(content, key)
+		case map[string]interface{}:
+			removeNulls(value)
+		case []interface{}:
+			for _, item := range value {
+				if item, ok := item.(map[string]interface{}); ok {
+					removeNulls(item)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package alphacontrollers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/utils/ptr"
+
+	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+
+	"github.com/coralogix/coralogix-operator/controllers/mock_clientset"
+)
+
+func TestParseExportKinds(t *testing.T) {
+	kinds, err := ParseExportKinds("")
+	assert.NoError(t, err)
+	assert.Equal(t, ExportKinds, kinds)
+
+	kinds, err = ParseExportKinds("webhooks, alerts")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"webhooks", "alerts"}, kinds)
+
+	_, err = ParseExportKinds("alerts,dashboards")
+	assert.Error(t, err)
+}
+
+func TestExportName(t *testing.T) {
+	assert.Equal(t, "high-5xx-rate", exportName("High 5xx Rate!"))
+	assert.Equal(t, "", exportName("---"))
+	assert.Len(t, exportName(string(bytes.Repeat([]byte("a"), 100))), maxExportNameLength)
+}
+
+func TestExport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := context.Background()
+
+	ruleGroupsClient := mock_clientset.NewMockRuleGroupsClientInterface(mockCtrl)
+	ruleGroupsClient.EXPECT().List(ctx).Return([]*cxsdk.RuleGroup{
+		{Id: wrapperspb.String("id-2"), Name: wrapperspb.String("Parsing Rules"), Enabled: wrapperspb.Bool(true), Order: wrapperspb.UInt32(2)},
+		{Id: wrapperspb.String("id-1"), Name: wrapperspb.String("Parsing Rules"), Enabled: wrapperspb.Bool(true), Order: wrapperspb.UInt32(1)},
+	}, nil)
+
+	webhooks := &cxsdk.ListAllOutgoingWebhooksResponse{}
+	assert.NoError(t, protojson.Unmarshal([]byte(`{"deployed": [{"id": "webhook-id", "name": "Webhook"}]}`), webhooks))
+	outboundWebhooksClient := mock_clientset.NewMockOutboundWebhooksClientInterface(mockCtrl)
+	outboundWebhooksClient.EXPECT().List(ctx, gomock.Any()).Return(webhooks, nil)
+	outboundWebhooksClient.EXPECT().Get(ctx, gomock.Any()).Return(&cxsdk.GetOutgoingWebhookResponse{
+		Webhook: &cxsdk.OutgoingWebhook{
+			Id:   wrapperspb.String("webhook-id"),
+			Name: wrapperspb.String("Webhook"),
+			Type: cxsdk.WebhookTypeGeneric,
+			Url:  wrapperspb.String("url"),
+			Config: &cxsdk.GenericWebhook{
+				GenericWebhook: &cxsdk.GenericWebhookConfig{
+					Uuid:    wrapperspb.String("uuid"),
+					Method:  cxsdk.GenericWebhookConfigGet,
+					Headers: map[string]string{"Authorization": "Bearer secret-token"},
+				},
+			},
+		},
+	}, nil)
+
+	recordingRuleGroupsClient := mock_clientset.NewMockRecordingRulesGroupsClientInterface(mockCtrl)
+	recordingRuleGroupsClient.EXPECT().List(ctx).Return(&cxsdk.ListRuleGroupSetResponse{
+		Sets: []*cxsdk.GetRuleGroupSetResponse{{Id: "set-id", Name: ptr.To("Recording Rules")}},
+	}, nil)
+
+	clientSet := mock_clientset.NewMockClientSetInterface(mockCtrl)
+	clientSet.EXPECT().RuleGroups().Return(ruleGroupsClient).AnyTimes()
+	clientSet.EXPECT().OutboundWebhooks().Return(outboundWebhooksClient).AnyTimes()
+	clientSet.EXPECT().RecordingRuleGroups().Return(recordingRuleGroupsClient).AnyTimes()
+
+	var out, warnings bytes.Buffer
+	exporter := &Exporter{ClientSet: clientSet, Namespace: "monitoring", Warnings: &warnings}
+	assert.NoError(t, exporter.Export(ctx, []string{"rulegroups", "webhooks", "recordingrules"}, &out))
+
+	manifests := out.String()
+	assert.Contains(t, manifests, "kind: RuleGroup\nmetadata:\n  annotations:\n    app.coralogix.com/import-id: id-1\n  name: parsing-rules\n  namespace: monitoring\n")
+	assert.Contains(t, manifests, "app.coralogix.com/import-id: id-2\n  name: parsing-rules-2\n")
+	assert.Contains(t, manifests, "kind: OutboundWebhook\nmetadata:\n  annotations:\n    app.coralogix.com/import-id: webhook-id\n  name: webhook\n")
+	assert.Contains(t, manifests, "genericWebhook:\n      headersFrom:\n        Authorization:\n          secretKeyRef:\n            key: Authorization\n            name: webhook-credentials\n      method: Get\n      url: url\n")
+	assert.NotContains(t, manifests, "secret-token")
+	assert.Equal(t, "warning: OutboundWebhook webhook takes its credentials from the Secret webhook-credentials, which should be created with the keys Authorization\n", warnings.String())
+	assert.Contains(t, manifests, "kind: RecordingRuleGroupSet\nmetadata:\n  annotations:\n    app.coralogix.com/import-id: set-id\n  name: recording-rules\n")
+	assert.NotContains(t, manifests, "status:")
+	assert.NotContains(t, manifests, "creationTimestamp")
+}
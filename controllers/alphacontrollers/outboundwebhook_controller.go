@@ -19,49 +19,75 @@ package alphacontrollers
 import (
 	"context"
 	"fmt"
-	"github.com/coralogix/coralogix-operator/controllers/clientset"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
 
 	utils "github.com/coralogix/coralogix-operator/apis"
 	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
 )
 
 // OutboundWebhookReconciler reconciles a OutboundWebhook object
 type OutboundWebhookReconciler struct {
 	client.Client
+	// Secrets reads the Secrets the credentials are taken from without caching them, e.g. the manager's APIReader.
+	// Only the metadata of the Secrets is watched.
+	Secrets                client.Reader
 	OutboundWebhooksClient clientset.OutboundWebhooksClientInterface
+	Accounts               ClientSetResolver
 	Scheme                 *runtime.Scheme
+	// ResyncPeriod is how often the remote outbound-webhook is compared with the spec, to catch out-of-band changes.
+	// Zero disables the periodic resync.
+	ResyncPeriod time.Duration
+	Recorder     record.EventRecorder
+	// MaxConcurrentReconciles is the number of resources reconciled in parallel. Zero means one.
+	MaxConcurrentReconciles int
+	// DeletionPolicy is what happens to the remote outbound-webhook of a deleted resource without a deletion policy.
+	// Empty means Delete.
+	DeletionPolicy coralogixv1alpha1.DeletionPolicy
+	// DryRun records the changes that would be made to the remote outbound-webhooks in the status and events, instead of making them.
+	DryRun bool
+	// WatchFilter restricts the reconciled outbound-webhooks to some namespaces and labels. The zero value reconciles all of them.
+	WatchFilter WatchFilter
 }
 
 //+kubebuilder:rbac:groups=coralogix.com,resources=outboundwebhooks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=coralogix.com,resources=outboundwebhooks/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=coralogix.com,resources=outboundwebhooks/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 var (
 	outboundWebhookFinalizerName = "outbound-webhook.coralogix.com/finalizer"
 )
 
-func (r *OutboundWebhookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	var (
-		resultError = ctrl.Result{RequeueAfter: 40}
-		err         error
-	)
+func (r *OutboundWebhookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	resultError := ctrl.Result{RequeueAfter: 40}
 
 	log := log.FromContext(ctx).WithValues(
 		"outboundWebhook", req.NamespacedName.Name,
@@ -79,17 +105,68 @@ func (r *OutboundWebhookReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return resultError, err
 	}
 
+	if paused, pauseErr := reconcilePaused(ctx, r.Client, r.Recorder, outboundWebhook); pauseErr != nil {
+		log.Error(pauseErr, "Error on checking whether the outbound-webhook reconciliation is paused")
+		return resultError, pauseErr
+	} else if paused {
+		// Neither changes nor deletions are propagated until the reconciliation is resumed.
+		log.V(1).Info("outbound-webhook reconciliation is paused")
+		return ctrl.Result{}, nil
+	}
+
+	// The plan is recomputed on each reconciliation.
+	outboundWebhook.Status.Plan = nil
+	defer func() {
+		if statusErr := updateSyncStatus(ctx, r.Client, outboundWebhook, r.DryRun, err); statusErr != nil {
+			log.Error(statusErr, "Error on updating outbound-webhook sync status")
+			if err == nil {
+				result, err = resultError, statusErr
+			}
+		}
+	}()
+
+	webhooksClient, err := r.webhooksClient(ctx, outboundWebhook)
+	if err != nil {
+		var released bool
+		if released, err = releaseWithoutAccount(ctx, r.Client, r.Recorder, outboundWebhook, outboundWebhookFinalizerName, "outbound-webhook", err); released {
+			log.Info("Account of the outbound-webhook was deleted, leaving the remote outbound-webhook in Coralogix")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Error on resolving Coralogix account")
+		return resultError, err
+	}
+
 	if ptr.Deref(outboundWebhook.Status.ID, "") == "" {
-		err = r.create(ctx, log, outboundWebhook)
+		var id string
+		if id, err = pendingImportID(ctx, r.Client, outboundWebhook, &coralogixv1alpha1.OutboundWebhookList{}); err != nil {
+			recordInvalidSpec(r.Recorder, outboundWebhook, "outbound-webhook", err)
+			log.Error(err, "Error on checking the outbound-webhook to import")
+			return resultError, err
+		}
+		if id != "" {
+			err = r.importRemote(ctx, log, webhooksClient, outboundWebhook, id)
+			if err != nil {
+				log.Error(err, "Error on importing outbound-webhook", "id", id)
+				return resultError, err
+			}
+			// The imported outbound-webhook is reconciled to the spec right away.
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		err = r.create(ctx, log, webhooksClient, outboundWebhook)
 		if err != nil {
 			log.Error(err, "Error on creating outbound-webhook")
 			return resultError, err
 		}
-		return ctrl.Result{}, nil
+		if err = r.test(ctx, log, webhooksClient, outboundWebhook); err != nil {
+			log.Error(err, "Error on testing outbound-webhook")
+			return resultError, err
+		}
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
 	}
 
 	if !outboundWebhook.ObjectMeta.DeletionTimestamp.IsZero() {
-		err = r.delete(ctx, log, outboundWebhook)
+		err = r.delete(ctx, log, webhooksClient, outboundWebhook)
 		if err != nil {
 			log.Error(err, "Error on deleting outbound-webhook")
 			return resultError, err
@@ -97,67 +174,135 @@ func (r *OutboundWebhookReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, nil
 	}
 
-	err = r.update(ctx, log, outboundWebhook)
+	err = r.update(ctx, log, webhooksClient, outboundWebhook)
 	if err != nil {
 		log.Error(err, "Error on updating outbound-webhook")
 		return resultError, err
 	}
+	if err = r.test(ctx, log, webhooksClient, outboundWebhook); err != nil {
+		log.Error(err, "Error on testing outbound-webhook")
+		return resultError, err
+	}
+
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+}
 
-	return ctrl.Result{}, nil
+func (r *OutboundWebhookReconciler) webhooksClient(ctx context.Context, webhook *coralogixv1alpha1.OutboundWebhook) (clientset.OutboundWebhooksClientInterface, error) {
+	if webhook.Spec.AccountRef == nil {
+		return r.OutboundWebhooksClient, nil
+	}
+	clientSet, err := resolveClientSet(ctx, r.Accounts, nil, webhook.Namespace, webhook.Spec.AccountRef)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet.OutboundWebhooks(), nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *OutboundWebhookReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&coralogixv1alpha1.OutboundWebhook{}).
+		For(&coralogixv1alpha1.OutboundWebhook{}, builder.WithPredicates(reconcilePredicate, r.WatchFilter.Predicate())).
+		Watches(&corev1.Namespace{}, enqueueNamespaceObjects(mgr.GetClient(), func() client.ObjectList { return &coralogixv1alpha1.OutboundWebhookList{} }),
+			builder.WithPredicates(namespaceLabelChangedPredicate, r.WatchFilter.NamespacePredicate())).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.enqueueSecretWebhooks), builder.OnlyMetadata,
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(secret client.Object) bool {
+				return r.WatchFilter.watchesNamespace(secret.GetNamespace())
+			}))).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
 
-func (r *OutboundWebhookReconciler) create(ctx context.Context, log logr.Logger, webhook *coralogixv1alpha1.OutboundWebhook) error {
-	createRequest, err := webhook.ExtractCreateOutboundWebhookRequest()
+func (r *OutboundWebhookReconciler) create(ctx context.Context, log logr.Logger, webhooksClient clientset.OutboundWebhooksClientInterface, webhook *coralogixv1alpha1.OutboundWebhook) error {
+	resolvedWebhook, err := r.resolveSecrets(ctx, webhook)
+	if err != nil {
+		return err
+	}
+	createRequest, err := resolvedWebhook.ExtractCreateOutboundWebhookRequest()
 	if err != nil {
-		return fmt.Errorf("error to extract create-request out of the outbound-webhook -\n%v", webhook)
+		recordInvalidSpec(r.Recorder, webhook, "outbound-webhook", err)
+		return fmt.Errorf("error to extract create-request out of the outbound-webhook: %w", err)
+	}
+
+	if r.DryRun {
+		log.Info("Dry run: not creating outbound-webhook")
+		recordPlan(r.Recorder, webhook, "outbound-webhook", coralogixv1alpha1.PlanActionCreate, "", createRequest)
+		return nil
 	}
 
-	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("Creating outbound-webhook-\n%s", protojson.Format(createRequest)))
-	createResponse, err := r.OutboundWebhooksClient.Create(ctx, createRequest)
+	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("Creating outbound-webhook-\n%s", redactedJSON(createRequest)))
+	createResponse, err := webhooksClient.Create(ctx, createRequest)
 	if err != nil {
-		return fmt.Errorf("error to create remote outbound-webhook - %s\n%w", protojson.Format(createRequest), err)
+		return fmt.Errorf("error to create remote outbound-webhook - %s\n%w", redactedJSON(createRequest), err)
 	}
-	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("outbound-webhook was created- %s", protojson.Format(createResponse)))
+	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("outbound-webhook was created- %s", redactedJSON(createResponse)))
+	r.Recorder.Eventf(webhook, corev1.EventTypeNormal, ReasonCreated, "Remote outbound-webhook %s was created", createResponse.Id.GetValue())
 
 	webhook.Status = coralogixv1alpha1.OutboundWebhookStatus{
 		ID:                  ptr.To(createResponse.Id.GetValue()),
 		Name:                webhook.Name,
 		OutboundWebhookType: &coralogixv1alpha1.OutboundWebhookTypeStatus{},
+		SyncStatus:          webhook.Status.SyncStatus,
 	}
 	if err = r.Status().Update(ctx, webhook); err != nil {
-		return fmt.Errorf("error to update outbound-webhook status -\n%v", webhook)
+		return fmt.Errorf("error to update outbound-webhook status: %w", err)
 	}
 
 	readRequest := &cxsdk.GetOutgoingWebhookRequest{Id: createResponse.Id}
-	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("Getting outbound-webhook -\n%s", protojson.Format(readRequest)))
-	readResponse, err := r.OutboundWebhooksClient.Get(ctx, readRequest)
+	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("Getting outbound-webhook -\n%s", redactedJSON(readRequest)))
+	readResponse, err := webhooksClient.Get(ctx, readRequest)
 	if err != nil {
-		return fmt.Errorf("error to get outbound-webhook -\n%v", webhook)
+		return fmt.Errorf("error to get outbound-webhook: %w", err)
 	}
-	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("outbound-webhook was read -\n%s", protojson.Format(readResponse)))
 
 	status, err := getOutboundWebhookStatus(readResponse.GetWebhook())
 	if err != nil {
-		return fmt.Errorf("error to flatten outbound-webhook -\n%v", webhook)
+		return fmt.Errorf("error to flatten outbound-webhook: %w", err)
 	}
+	status.OutboundWebhookType.HashSecrets()
 
+	status.SyncStatus = webhook.Status.SyncStatus
 	webhook.Status = *status
 	if err = r.Status().Update(ctx, webhook); err != nil {
-		return fmt.Errorf("error to update outbound-webhook status -\n%v", webhook)
+		return fmt.Errorf("error to update outbound-webhook status: %w", err)
 	}
 
 	if !controllerutil.ContainsFinalizer(webhook, outboundWebhookFinalizerName) {
 		controllerutil.AddFinalizer(webhook, outboundWebhookFinalizerName)
 	}
 	if err = r.Client.Update(ctx, webhook); err != nil {
-		return fmt.Errorf("error to update outbound-webhook -\n%v", webhook)
+		return fmt.Errorf("error to update outbound-webhook: %w", err)
+	}
+
+	return nil
+}
+
+// importRemote binds the outbound-webhook to the existing remote outbound-webhook id, instead of creating one.
+func (r *OutboundWebhookReconciler) importRemote(ctx context.Context, log logr.Logger, webhooksClient clientset.OutboundWebhooksClientInterface, webhook *coralogixv1alpha1.OutboundWebhook, id string) error {
+	readRequest := &cxsdk.GetOutgoingWebhookRequest{Id: wrapperspb.String(id)}
+	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("Importing outbound-webhook -\n%s", redactedJSON(readRequest)))
+	readResponse, err := webhooksClient.Get(ctx, readRequest)
+	if err != nil {
+		return fmt.Errorf("error to get outbound-webhook %s to import: %w", id, err)
+	}
+
+	status, err := getOutboundWebhookStatus(readResponse.GetWebhook())
+	if err != nil {
+		return fmt.Errorf("error to flatten outbound-webhook: %w", err)
+	}
+	status.OutboundWebhookType.HashSecrets()
+	status.SyncStatus = webhook.Status.SyncStatus
+	status.ImportedID = id
+	webhook.Status = *status
+	if err = r.Status().Update(ctx, webhook); err != nil {
+		return fmt.Errorf("error to update outbound-webhook status: %w", err)
+	}
+	r.Recorder.Eventf(webhook, corev1.EventTypeNormal, ReasonImported, "Remote outbound-webhook %s was imported", id)
+
+	if !controllerutil.ContainsFinalizer(webhook, outboundWebhookFinalizerName) {
+		controllerutil.AddFinalizer(webhook, outboundWebhookFinalizerName)
+		if err = r.Client.Update(ctx, webhook); err != nil {
+			return fmt.Errorf("error to update outbound-webhook: %w", err)
+		}
 	}
 
 	return nil
@@ -356,59 +501,249 @@ func getOutgoingWebhookEmailGroupStatus(group *cxsdk.EmailGroupConfig) *coralogi
 	}
 }
 
-func (r *OutboundWebhookReconciler) update(ctx context.Context, log logr.Logger, webhook *coralogixv1alpha1.OutboundWebhook) error {
-	updateReq, err := webhook.ExtractUpdateOutboundWebhookRequest()
+func (r *OutboundWebhookReconciler) update(ctx context.Context, log logr.Logger, webhooksClient clientset.OutboundWebhooksClientInterface, webhook *coralogixv1alpha1.OutboundWebhook) error {
+	resolvedWebhook, err := r.resolveSecrets(ctx, webhook)
 	if err != nil {
-		return fmt.Errorf("error to parse update outbound-webhook request -\n%v", webhook)
+		return err
 	}
 
-	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("updating outbound-webhook\n%s", protojson.Format(updateReq)))
-	_, err = r.OutboundWebhooksClient.Update(ctx, updateReq)
+	log.V(int(zapcore.DebugLevel)).Info("Getting outbound-webhook from remote", "id", webhook.Status.ID)
+	remoteOutboundWebhook, err := webhooksClient.Get(ctx,
+		&cxsdk.GetOutgoingWebhookRequest{
+			Id: utils.StringPointerToWrapperspbString(webhook.Status.ID),
+		},
+	)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			webhook.Status = coralogixv1alpha1.OutboundWebhookStatus{}
+			return r.resetStatus(ctx, webhook)
+		}
+		return fmt.Errorf("error to get outbound-webhook: %w", err)
+	}
+
+	actualStatus, err := getOutboundWebhookStatus(remoteOutboundWebhook.GetWebhook())
+	if err != nil {
+		return fmt.Errorf("error to flatten outbound-webhook: %w", err)
+	}
+	actualStatus.OutboundWebhookType.HashSecrets()
+	// The status holds the hashes of the sensitive values, so it is compared with the hashed spec.
+	hashedSpec := resolvedWebhook.Spec.DeepCopy()
+	hashedSpec.OutboundWebhookType = *resolvedWebhook.Spec.OutboundWebhookType.HashSecrets()
+
+	equal, diff := hashedSpec.DeepEqual(actualStatus)
+	if equal {
+		actualStatus.SyncStatus = webhook.Status.SyncStatus
+		actualStatus.LastTest = webhook.Status.LastTest
+		actualStatus.LastDrift = resolvedDrift(webhook.Status.LastDrift)
+		if equality.Semantic.DeepEqual(webhook.Status, *actualStatus) {
+			return nil
+		}
+		webhook.Status = *actualStatus
+		if err = r.Status().Update(ctx, webhook); err != nil {
+			return fmt.Errorf("error to update outbound-webhook status: %w", err)
+		}
+		return nil
+	}
+
+	log.V(int(zapcore.DebugLevel)).Info("Found diff between spec and remote outbound-webhook", "diff", diff)
+
+	// The spec was applied already, so the remote outbound-webhook was changed outside of the operator.
+	if specApplied, _ := hashedSpec.DeepEqual(&webhook.Status); specApplied {
+		drift := coralogixv1alpha1.NewDrift(diff, webhook.Spec.DriftPolicy.Enforces())
+		if !drift.Enforced {
+			log.Info("Remote outbound-webhook drifted from the spec, reporting it only", "field", drift.Field)
+			if drift.SameAs(webhook.Status.LastDrift) {
+				return nil
+			}
+			recordDrift(r.Recorder, webhook, "outbound-webhook", drift)
+			webhook.Status.LastDrift = drift
 			if err = r.Status().Update(ctx, webhook); err != nil {
-				return fmt.Errorf("error to update outbound-webhook status -\n%v", webhook)
+				return fmt.Errorf("error to update outbound-webhook status: %w", err)
 			}
-			return fmt.Errorf("outbound-webhook %s not found on remote, recreating it", *webhook.Status.ID)
+			return nil
+		}
+		recordDrift(r.Recorder, webhook, "outbound-webhook", drift)
+	}
+
+	updateReq, err := resolvedWebhook.ExtractUpdateOutboundWebhookRequest()
+	if err != nil {
+		recordInvalidSpec(r.Recorder, webhook, "outbound-webhook", err)
+		return fmt.Errorf("error to parse update outbound-webhook request: %w", err)
+	}
+
+	if r.DryRun {
+		log.Info("Dry run: not updating outbound-webhook", "id", webhook.Status.ID, "field", diff.Name)
+		recordPlan(r.Recorder, webhook, "outbound-webhook", coralogixv1alpha1.PlanActionUpdate, diff.Name, updateReq)
+		return nil
+	}
+
+	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("updating outbound-webhook\n%s", redactedJSON(updateReq)))
+	_, err = webhooksClient.Update(ctx, updateReq)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return r.resetStatus(ctx, webhook)
 		}
-		return fmt.Errorf("error to update outbound-webhook -\n%v", webhook)
+		return fmt.Errorf("error to update outbound-webhook: %w", err)
 	}
+	r.Recorder.Eventf(webhook, corev1.EventTypeNormal, ReasonUpdated, "Remote outbound-webhook %s was updated", *webhook.Status.ID)
 
 	log.V(int(zapcore.DebugLevel)).Info("Getting outbound-webhook from remote", "id", webhook.Status.ID)
-	remoteOutboundWebhook, err := r.OutboundWebhooksClient.Get(ctx,
+	remoteOutboundWebhook, err = webhooksClient.Get(ctx,
 		&cxsdk.GetOutgoingWebhookRequest{
 			Id: utils.StringPointerToWrapperspbString(webhook.Status.ID),
 		},
 	)
 	if err != nil {
-		return fmt.Errorf("error to get outbound-webhook -\n%v", webhook)
+		return fmt.Errorf("error to get outbound-webhook: %w", err)
 	}
-	log.V(int(zapcore.DebugLevel)).Info(fmt.Sprintf("outbound-webhook was read\n%s", protojson.Format(remoteOutboundWebhook)))
 
 	status, err := getOutboundWebhookStatus(remoteOutboundWebhook.GetWebhook())
 	if err != nil {
-		return fmt.Errorf("error to flatten outbound-webhook -\n%v", webhook)
+		return fmt.Errorf("error to flatten outbound-webhook: %w", err)
 	}
+	status.OutboundWebhookType.HashSecrets()
+	status.SyncStatus = webhook.Status.SyncStatus
+	status.LastTest = webhook.Status.LastTest
+	status.LastDrift = coralogixv1alpha1.NewDrift(diff, true)
 	webhook.Status = *status
 	if err = r.Status().Update(ctx, webhook); err != nil {
-		return fmt.Errorf("error to update outbound-webhook status -\n%v", webhook)
+		return fmt.Errorf("error to update outbound-webhook status: %w", err)
 	}
 
 	return nil
 }
 
-func (r *OutboundWebhookReconciler) delete(ctx context.Context, log logr.Logger, webhook *coralogixv1alpha1.OutboundWebhook) error {
-	log.V(int(zapcore.DebugLevel)).Info("Deleting outbound-webhook from remote", "id", webhook.Status.ID)
-	if _, err := r.OutboundWebhooksClient.Delete(ctx,
-		&cxsdk.DeleteOutgoingWebhookRequest{Id: wrapperspb.String(*webhook.Status.ID)}); err != nil && status.Code(err) != codes.NotFound {
-		return fmt.Errorf("error to delete outbound-webhook -\n%v", webhook)
+// test sends a test notification through the remote outbound-webhook when the TestWebhookAnnotation changed since the
+// last test, and records its outcome in the status and an event. A failed test doesn't fail the reconciliation.
+func (r *OutboundWebhookReconciler) test(ctx context.Context, log logr.Logger, webhooksClient clientset.OutboundWebhooksClientInterface, webhook *coralogixv1alpha1.OutboundWebhook) error {
+	trigger := strings.TrimSpace(webhook.GetAnnotations()[coralogixv1alpha1.TestWebhookAnnotation])
+	id := ptr.Deref(webhook.Status.ID, "")
+	if trigger == "" || id == "" || (webhook.Status.LastTest != nil && webhook.Status.LastTest.Trigger == trigger) {
+		return nil
+	}
+
+	if r.DryRun {
+		// The trigger isn't recorded, so the test notification is sent once the operator leaves dry-run mode.
+		log.Info("Dry run: not testing outbound-webhook", "id", id)
+		r.Recorder.Eventf(webhook, corev1.EventTypeNormal, ReasonPlanned, "Dry run: test notification of outbound-webhook %s would be sent", id)
+		return nil
+	}
+
+	log.V(int(zapcore.DebugLevel)).Info("Testing outbound-webhook", "id", id)
+	resp, err := webhooksClient.TestByID(ctx, &cxsdk.TestExistingOutgoingWebhookRequest{Id: wrapperspb.String(id)})
+	lastTest := newOutboundWebhookTest(trigger, resp, err)
+	if lastTest.Succeeded {
+		r.Recorder.Eventf(webhook, corev1.EventTypeNormal, ReasonTestSucceeded, "Test notification of outbound-webhook %s was delivered", id)
+	} else {
+		log.Info("Test notification of outbound-webhook failed", "id", id, "error", lastTest.Error)
+		r.Recorder.Eventf(webhook, corev1.EventTypeWarning, ReasonTestFailed, "Test notification of outbound-webhook %s failed: %s", id, lastTest.Error)
+	}
+
+	webhook.Status.LastTest = lastTest
+	if err = r.Status().Update(ctx, webhook); err != nil {
+		return fmt.Errorf("error to update outbound-webhook status: %w", err)
+	}
+	return nil
+}
+
+// newOutboundWebhookTest returns the outcome of the test triggered by trigger, which returned resp and err.
+func newOutboundWebhookTest(trigger string, resp clientset.TestOutgoingWebhookResponse, err error) *coralogixv1alpha1.OutboundWebhookTest {
+	test := &coralogixv1alpha1.OutboundWebhookTest{Trigger: trigger, Time: metav1.Now()}
+	switch {
+	case err != nil:
+		test.Error = fmt.Sprintf("error on testing outbound-webhook: %s", err)
+	case resp.GetSuccess() != nil:
+		test.Succeeded = true
+	case resp.GetFailure() != nil:
+		failure := resp.GetFailure()
+		test.Error = failure.GetErrorMessage().GetValue()
+		if test.Error == "" {
+			test.Error = failure.GetDisplayMessage().GetValue()
+		}
+		if failure.StatusCode != nil {
+			test.StatusCode = ptr.To(int32(failure.GetStatusCode().GetValue()))
+		}
+	default:
+		test.Error = "the test returned no outcome"
+	}
+	return test
+}
+
+// resolveSecrets returns a copy of the outbound-webhook with the values of the Secret keys its spec refers to, read from
+// its namespace, in place of the references.
+func (r *OutboundWebhookReconciler) resolveSecrets(ctx context.Context, webhook *coralogixv1alpha1.OutboundWebhook) (*coralogixv1alpha1.OutboundWebhook, error) {
+	webhookType, err := webhook.Spec.OutboundWebhookType.ResolveSecrets(func(ref *corev1.SecretKeySelector) (string, error) {
+		secret := &corev1.Secret{}
+		if err := r.Secrets.Get(ctx, client.ObjectKey{Namespace: webhook.Namespace, Name: ref.Name}, secret); err != nil {
+			if errors.IsNotFound(err) && ptr.Deref(ref.Optional, false) {
+				return "", nil
+			}
+			return "", fmt.Errorf("error on getting secret %s: %w", ref.Name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok && !ptr.Deref(ref.Optional, false) {
+			return "", fmt.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+		}
+		return string(value), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error on resolving outbound-webhook secrets: %w", err)
+	}
+
+	resolvedWebhook := webhook.DeepCopy()
+	resolvedWebhook.Spec.OutboundWebhookType = *webhookType
+	return resolvedWebhook, nil
+}
+
+// enqueueSecretWebhooks enqueues the outbound-webhooks taking values from a Secret, so they are updated when it changes.
+// Only the metadata of the Secret is watched, so secret is a *metav1.PartialObjectMetadata.
+func (r *OutboundWebhookReconciler) enqueueSecretWebhooks(ctx context.Context, secret client.Object) []reconcile.Request {
+	webhooks := &coralogixv1alpha1.OutboundWebhookList{}
+	if err := r.List(ctx, webhooks, client.InNamespace(secret.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Error on listing outbound-webhooks of secret", "namespace", secret.GetNamespace(), "secret", secret.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, webhook := range webhooks.Items {
+		if slices.Contains(webhook.Spec.OutboundWebhookType.SecretNames(), secret.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&webhook)})
+		}
+	}
+	return requests
+}
+
+// resetStatus clears the status of an outbound-webhook that was not found on remote, so it is recreated on the next reconcile.
+func (r *OutboundWebhookReconciler) resetStatus(ctx context.Context, webhook *coralogixv1alpha1.OutboundWebhook) error {
+	id := ptr.Deref(webhook.Status.ID, "")
+	r.Recorder.Eventf(webhook, corev1.EventTypeWarning, ReasonRemoteNotFound, "Remote outbound-webhook %s was not found, recreating it", id)
+	webhook.Status = coralogixv1alpha1.OutboundWebhookStatus{SyncStatus: webhook.Status.SyncStatus}
+	if err := r.Status().Update(ctx, webhook); err != nil {
+		return fmt.Errorf("error to update outbound-webhook status: %w", err)
+	}
+	return fmt.Errorf("outbound-webhook %s not found on remote, recreating it", id)
+}
+
+func (r *OutboundWebhookReconciler) delete(ctx context.Context, log logr.Logger, webhooksClient clientset.OutboundWebhooksClientInterface, webhook *coralogixv1alpha1.OutboundWebhook) error {
+	if webhook.Spec.DeletionPolicy.Retains(r.DeletionPolicy) {
+		log.V(int(zapcore.DebugLevel)).Info("Retaining outbound-webhook on remote", "id", webhook.Status.ID)
+		r.Recorder.Eventf(webhook, corev1.EventTypeNormal, ReasonRetained, "Remote outbound-webhook %s was retained", *webhook.Status.ID)
+	} else if r.DryRun {
+		// The resource is not kept until the operator leaves dry-run mode, so the remote outbound-webhook is left as is.
+		log.Info("Dry run: not deleting outbound-webhook", "id", webhook.Status.ID)
+		recordPlan(r.Recorder, webhook, "outbound-webhook", coralogixv1alpha1.PlanActionDelete, "", nil)
+	} else {
+		log.V(int(zapcore.DebugLevel)).Info("Deleting outbound-webhook from remote", "id", webhook.Status.ID)
+		if _, err := webhooksClient.Delete(ctx,
+			&cxsdk.DeleteOutgoingWebhookRequest{Id: wrapperspb.String(*webhook.Status.ID)}); err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("error to delete outbound-webhook: %w", err)
+		}
+		log.V(int(zapcore.DebugLevel)).Info("outbound-webhook was deleted from remote", "id", webhook.Status.ID)
+		r.Recorder.Eventf(webhook, corev1.EventTypeNormal, ReasonDeleted, "Remote outbound-webhook %s was deleted", *webhook.Status.ID)
 	}
-	log.V(int(zapcore.DebugLevel)).Info("outbound-webhook was deleted from remote", "id", webhook.Status.ID)
 
 	controllerutil.RemoveFinalizer(webhook, outboundWebhookFinalizerName)
 	if err := r.Update(ctx, webhook); err != nil {
-		return fmt.Errorf("error to update outbound-webhook -\n%v", webhook)
+		return fmt.Errorf("error to update outbound-webhook: %w", err)
 	}
 
 	return nil
@@ -6,20 +6,29 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
 
 	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
 	"github.com/coralogix/coralogix-operator/controllers/mock_clientset"
 )
 
@@ -44,7 +53,9 @@ func setupOutboundWebhooksReconciler(t *testing.T, ctx context.Context, outbound
 	assert.NoError(t, err)
 	r := OutboundWebhookReconciler{
 		Client:                 withWatch,
+		Secrets:                mgr.GetAPIReader(),
 		Scheme:                 mgr.GetScheme(),
+		Recorder:               mgr.GetEventRecorderFor("coralogix-operator"),
 		OutboundWebhooksClient: outboundWebhooksClient,
 	}
 	r.SetupWithManager(mgr)
@@ -148,6 +159,100 @@ func TestOutboundWebhooksCreation(t *testing.T) {
 	}
 }
 
+func TestOutboundWebhookImport(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectName string
+		params     func(params PrepareOutboundWebhooksParams)
+		shouldFail bool
+	}{
+		{
+			name:       "outbound-webhook import success",
+			objectName: "outbound-webhook-import-success",
+			shouldFail: false,
+			params: func(params PrepareOutboundWebhooksParams) {
+				params.outboundWebhooksClient.EXPECT().Get(params.ctx, &cxsdk.GetOutgoingWebhookRequest{Id: wrapperspb.String("imported-id")}).Return(&cxsdk.GetOutgoingWebhookResponse{
+					Webhook: &cxsdk.OutgoingWebhook{
+						Id:   wrapperspb.String("imported-id"),
+						Name: wrapperspb.String("name"),
+						Type: cxsdk.WebhookTypeGeneric,
+						Url:  wrapperspb.String("url"),
+						Config: &cxsdk.GenericWebhook{
+							GenericWebhook: &cxsdk.GenericWebhookConfig{
+								Uuid:   wrapperspb.String("uuid"),
+								Method: cxsdk.GenericWebhookConfigGet,
+							},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:       "outbound-webhook import of a missing webhook",
+			objectName: "outbound-webhook-import-missing",
+			shouldFail: true,
+			params: func(params PrepareOutboundWebhooksParams) {
+				params.outboundWebhooksClient.EXPECT().Get(params.ctx, gomock.Any()).Return(nil, status.Error(codes.NotFound, "not found"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			// Create is not expected, so the test fails if the reconciler creates a new webhook.
+			outboundWebhooksClient := mock_clientset.NewMockOutboundWebhooksClientInterface(controller)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			tt.params(PrepareOutboundWebhooksParams{
+				ctx:                    ctx,
+				outboundWebhooksClient: outboundWebhooksClient,
+			})
+
+			reconciler, watcher := setupOutboundWebhooksReconciler(t, ctx, outboundWebhooksClient)
+
+			outboundWebhook := coralogixv1alpha1.OutboundWebhook{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        tt.objectName,
+					Namespace:   "default",
+					Annotations: map[string]string{coralogixv1alpha1.ImportIDAnnotation: "imported-id"},
+				},
+				Spec: coralogixv1alpha1.OutboundWebhookSpec{
+					Name: "name",
+					OutboundWebhookType: coralogixv1alpha1.OutboundWebhookType{
+						GenericWebhook: &coralogixv1alpha1.GenericWebhook{Url: "url", Method: "Get"},
+					},
+				},
+			}
+			err := reconciler.Client.Create(ctx, &outboundWebhook)
+			assert.NoError(t, err)
+
+			<-watcher.ResultChan()
+
+			_, err = reconciler.Reconcile(ctx, ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: outboundWebhook.Namespace,
+					Name:      outboundWebhook.Name,
+				},
+			})
+
+			if tt.shouldFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			err = reconciler.Get(ctx, client.ObjectKeyFromObject(&outboundWebhook), &outboundWebhook)
+			assert.NoError(t, err)
+			assert.Equal(t, "imported-id", pointer.StringDeref(outboundWebhook.Status.ID, ""))
+		})
+	}
+}
+
 func TestOutboundWebhookUpdate(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -176,7 +281,7 @@ func TestOutboundWebhookUpdate(t *testing.T) {
 							},
 						},
 					},
-				}, nil)
+				}, nil).Times(2)
 				params.outboundWebhooksClient.EXPECT().Update(params.ctx, gomock.Any()).Return(&cxsdk.UpdateOutgoingWebhookResponse{}, nil)
 				params.outboundWebhooksClient.EXPECT().Get(params.ctx, gomock.Any()).Return(&cxsdk.GetOutgoingWebhookResponse{
 					Webhook: &cxsdk.OutgoingWebhook{
@@ -351,6 +456,43 @@ func TestOutboundWebhookDeletion(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "outbound-webhook deletion retains remote",
+			shouldFail: false,
+			params: func(params PrepareOutboundWebhooksParams) {
+				params.outboundWebhooksClient.EXPECT().Create(params.ctx, gomock.Any()).Return(&cxsdk.CreateOutgoingWebhookResponse{Id: wrapperspb.String("id")}, nil)
+				params.outboundWebhooksClient.EXPECT().Get(params.ctx, gomock.Any()).Return(&cxsdk.GetOutgoingWebhookResponse{
+					Webhook: &cxsdk.OutgoingWebhook{
+						Id:   wrapperspb.String("id"),
+						Name: wrapperspb.String("name"),
+						Type: cxsdk.WebhookTypeGeneric,
+						Url:  wrapperspb.String("url"),
+						Config: &cxsdk.GenericWebhook{
+							GenericWebhook: &cxsdk.GenericWebhookConfig{
+								Uuid:   wrapperspb.String("uuid"),
+								Method: cxsdk.GenericWebhookConfigGet,
+							},
+						},
+					},
+				}, nil)
+			},
+			outboundWebhook: coralogixv1alpha1.OutboundWebhook{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "outbound-webhook-deletion-retain",
+					Namespace: "default",
+				},
+				Spec: coralogixv1alpha1.OutboundWebhookSpec{
+					Name: "name",
+					OutboundWebhookType: coralogixv1alpha1.OutboundWebhookType{
+						GenericWebhook: &coralogixv1alpha1.GenericWebhook{
+							Url:    "url",
+							Method: "Get",
+						},
+					},
+					DeletionPolicy: ptr.To(coralogixv1alpha1.DeletionPolicyRetain),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -402,3 +544,218 @@ func TestOutboundWebhookDeletion(t *testing.T) {
 		})
 	}
 }
+
+func TestOutboundWebhookSecrets(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	outboundWebhooksClient := mock_clientset.NewMockOutboundWebhooksClientInterface(controller)
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "monitoring"},
+		Data:       map[string][]byte{"url": []byte("https://hooks.slack.com/services/first")},
+	}
+	webhook := &coralogixv1alpha1.OutboundWebhook{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "monitoring"},
+		Spec: coralogixv1alpha1.OutboundWebhookSpec{
+			Name: "slack",
+			OutboundWebhookType: coralogixv1alpha1.OutboundWebhookType{
+				Slack: &coralogixv1alpha1.Slack{UrlFrom: &coralogixv1alpha1.ValueFrom{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "slack"},
+					Key:                  "url",
+				}}},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(secret, webhook, namespace).
+		WithStatusSubresource(webhook).
+		Build()
+	reconciler := OutboundWebhookReconciler{
+		Client:                 c,
+		Secrets:                c,
+		Scheme:                 scheme,
+		Recorder:               record.NewFakeRecorder(10),
+		OutboundWebhooksClient: outboundWebhooksClient,
+	}
+
+	ctx := context.Background()
+	remoteWebhook := func(url string) *cxsdk.GetOutgoingWebhookResponse {
+		return &cxsdk.GetOutgoingWebhookResponse{Webhook: &cxsdk.OutgoingWebhook{
+			Id:     wrapperspb.String("id"),
+			Name:   wrapperspb.String("slack"),
+			Type:   cxsdk.WebhookTypeSlack,
+			Url:    wrapperspb.String(url),
+			Config: &cxsdk.SlackWebhook{Slack: &cxsdk.SlackConfig{}},
+		}}
+	}
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(webhook)}
+
+	// The remote outbound-webhook is created with the Secret value, of which the status only shows the hash.
+	outboundWebhooksClient.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *cxsdk.CreateOutgoingWebhookRequest) (*cxsdk.CreateOutgoingWebhookResponse, error) {
+			assert.Equal(t, "https://hooks.slack.com/services/first", req.GetData().GetUrl().GetValue())
+			return &cxsdk.CreateOutgoingWebhookResponse{Id: wrapperspb.String("id")}, nil
+		})
+	outboundWebhooksClient.EXPECT().Get(ctx, gomock.Any()).Return(remoteWebhook("https://hooks.slack.com/services/first"), nil)
+	_, err := reconciler.Reconcile(ctx, request)
+	assert.NoError(t, err)
+
+	latest := &coralogixv1alpha1.OutboundWebhook{}
+	assert.NoError(t, c.Get(ctx, request.NamespacedName, latest))
+	assert.Equal(t, coralogixv1alpha1.HashSecret("https://hooks.slack.com/services/first"), latest.Status.OutboundWebhookType.Slack.Url)
+
+	// Nothing changed, so the remote outbound-webhook is not updated.
+	outboundWebhooksClient.EXPECT().Get(ctx, gomock.Any()).Return(remoteWebhook("https://hooks.slack.com/services/first"), nil)
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NoError(t, err)
+
+	// A new Secret value is pushed as a change of the spec, and the recorded diff only shows its hash.
+	secret.Data["url"] = []byte("https://hooks.slack.com/services/second")
+	assert.NoError(t, c.Update(ctx, secret))
+	outboundWebhooksClient.EXPECT().Get(ctx, gomock.Any()).Return(remoteWebhook("https://hooks.slack.com/services/first"), nil)
+	outboundWebhooksClient.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *cxsdk.UpdateOutgoingWebhookRequest) (*cxsdk.UpdateOutgoingWebhookResponse, error) {
+			assert.Equal(t, "https://hooks.slack.com/services/second", req.GetData().GetUrl().GetValue())
+			return &cxsdk.UpdateOutgoingWebhookResponse{}, nil
+		})
+	outboundWebhooksClient.EXPECT().Get(ctx, gomock.Any()).Return(remoteWebhook("https://hooks.slack.com/services/second"), nil)
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Get(ctx, request.NamespacedName, latest))
+	assert.Equal(t, coralogixv1alpha1.HashSecret("https://hooks.slack.com/services/second"), latest.Status.OutboundWebhookType.Slack.Url)
+	assert.Equal(t, "OutboundWebhookStatus.OutboundWebhookType.OutboundWebhookType.Slack.Url", latest.Status.LastDrift.Field)
+	assert.NotContains(t, latest.Status.LastDrift.Desired+latest.Status.LastDrift.Actual, "hooks.slack.com")
+
+	// A URL changed in Coralogix is caught as a drift, although the status only holds the hashes.
+	recorder := record.NewFakeRecorder(10)
+	reconciler.Recorder = recorder
+	outboundWebhooksClient.EXPECT().Get(ctx, gomock.Any()).Return(remoteWebhook("https://hooks.slack.com/services/third"), nil)
+	outboundWebhooksClient.EXPECT().Update(ctx, gomock.Any()).Return(&cxsdk.UpdateOutgoingWebhookResponse{}, nil)
+	outboundWebhooksClient.EXPECT().Get(ctx, gomock.Any()).Return(remoteWebhook("https://hooks.slack.com/services/second"), nil)
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.Contains(t, <-recorder.Events, ReasonDriftDetected)
+
+	// Only the metadata of the Secrets is watched.
+	requests := reconciler.enqueueSecretWebhooks(ctx, &metav1.PartialObjectMetadata{ObjectMeta: secret.ObjectMeta})
+	assert.Equal(t, []reconcile.Request{request}, requests)
+}
+
+type testWebhookResponse struct {
+	success *cxsdk.TestOutgoingWebhookSuccess
+	failure *cxsdk.TestOutgoingWebhookFailure
+}
+
+func (r testWebhookResponse) GetSuccess() *cxsdk.TestOutgoingWebhookSuccess { return r.success }
+
+func (r testWebhookResponse) GetFailure() *cxsdk.TestOutgoingWebhookFailure { return r.failure }
+
+func TestOutboundWebhookTest(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	outboundWebhooksClient := mock_clientset.NewMockOutboundWebhooksClientInterface(controller)
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+
+	webhook := &coralogixv1alpha1.OutboundWebhook{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "slack",
+			Namespace:   "monitoring",
+			Annotations: map[string]string{coralogixv1alpha1.TestWebhookAnnotation: "1"},
+			Finalizers:  []string{outboundWebhookFinalizerName},
+		},
+		Spec: coralogixv1alpha1.OutboundWebhookSpec{
+			Name: "slack",
+			OutboundWebhookType: coralogixv1alpha1.OutboundWebhookType{
+				Slack: &coralogixv1alpha1.Slack{Url: "https://hooks.slack.com/services/x"},
+			},
+		},
+		Status: coralogixv1alpha1.OutboundWebhookStatus{ID: ptr.To("id")},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(webhook, namespace).
+		WithStatusSubresource(webhook).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	reconciler := OutboundWebhookReconciler{
+		Client:                 c,
+		Secrets:                c,
+		Scheme:                 scheme,
+		Recorder:               recorder,
+		OutboundWebhooksClient: outboundWebhooksClient,
+	}
+
+	ctx := context.Background()
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(webhook)}
+	outboundWebhooksClient.EXPECT().Get(ctx, gomock.Any()).Return(&cxsdk.GetOutgoingWebhookResponse{Webhook: &cxsdk.OutgoingWebhook{
+		Id:     wrapperspb.String("id"),
+		Name:   wrapperspb.String("slack"),
+		Type:   cxsdk.WebhookTypeSlack,
+		Url:    wrapperspb.String("https://hooks.slack.com/services/x"),
+		Config: &cxsdk.SlackWebhook{Slack: &cxsdk.SlackConfig{}},
+	}}, nil).AnyTimes()
+
+	// A failed test is recorded without failing the reconciliation.
+	outboundWebhooksClient.EXPECT().TestByID(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *cxsdk.TestExistingOutgoingWebhookRequest) (clientset.TestOutgoingWebhookResponse, error) {
+			assert.Equal(t, "id", req.GetId().GetValue())
+			return testWebhookResponse{failure: &cxsdk.TestOutgoingWebhookFailure{
+				ErrorMessage: wrapperspb.String("channel_not_found"),
+				StatusCode:   wrapperspb.UInt32(404),
+			}}, nil
+		})
+	_, err := reconciler.Reconcile(ctx, request)
+	assert.NoError(t, err)
+
+	latest := &coralogixv1alpha1.OutboundWebhook{}
+	assert.NoError(t, c.Get(ctx, request.NamespacedName, latest))
+	if assert.NotNil(t, latest.Status.LastTest) {
+		assert.Equal(t, "1", latest.Status.LastTest.Trigger)
+		assert.False(t, latest.Status.LastTest.Succeeded)
+		assert.Equal(t, ptr.To(int32(404)), latest.Status.LastTest.StatusCode)
+		assert.Equal(t, "channel_not_found", latest.Status.LastTest.Error)
+	}
+	assert.Equal(t, "Warning TestFailed Test notification of outbound-webhook id failed: channel_not_found", <-recorder.Events)
+
+	// The test is not sent again until the annotation changes.
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Get(ctx, request.NamespacedName, latest))
+	latest.Annotations[coralogixv1alpha1.TestWebhookAnnotation] = "2"
+	assert.NoError(t, c.Update(ctx, latest))
+	outboundWebhooksClient.EXPECT().TestByID(ctx, gomock.Any()).
+		Return(testWebhookResponse{success: &cxsdk.TestOutgoingWebhookSuccess{}}, nil)
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Get(ctx, request.NamespacedName, latest))
+	if assert.NotNil(t, latest.Status.LastTest) {
+		assert.Equal(t, "2", latest.Status.LastTest.Trigger)
+		assert.True(t, latest.Status.LastTest.Succeeded)
+		assert.Empty(t, latest.Status.LastTest.Error)
+	}
+	assert.Equal(t, "Normal TestSucceeded Test notification of outbound-webhook id was delivered", <-recorder.Events)
+
+	// In dry-run mode, the test is only reported, and is sent once the operator leaves it.
+	reconciler.DryRun = true
+	latest.Annotations[coralogixv1alpha1.TestWebhookAnnotation] = "3"
+	assert.NoError(t, c.Update(ctx, latest))
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NoError(t, err)
+
+	assert.NoError(t, c.Get(ctx, request.NamespacedName, latest))
+	if assert.NotNil(t, latest.Status.LastTest) {
+		assert.Equal(t, "2", latest.Status.LastTest.Trigger)
+	}
+	assert.Equal(t, "Normal Planned Dry run: test notification of outbound-webhook id would be sent", <-recorder.Events)
+}
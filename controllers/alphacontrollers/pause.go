@@ -0,0 +1,94 @@
+package alphacontrollers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// pausedReason returns why the reconciliation of obj is paused, or an empty string when it is not.
+func pausedReason(ctx context.Context, c client.Client, obj client.Object) (string, error) {
+	if obj.GetAnnotations()[coralogixv1alpha1.ReconcileAnnotation] == coralogixv1alpha1.ReconcilePaused {
+		return fmt.Sprintf("Reconciliation is paused by the %s annotation", coralogixv1alpha1.ReconcileAnnotation), nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: obj.GetNamespace()}, namespace); err != nil {
+		return "", fmt.Errorf("error on getting namespace %s: %w", obj.GetNamespace(), err)
+	}
+	if namespace.GetLabels()[coralogixv1alpha1.ReconcileAnnotation] == coralogixv1alpha1.ReconcilePaused {
+		return fmt.Sprintf("Reconciliation is paused by the %s label of namespace %s", coralogixv1alpha1.ReconcileAnnotation, namespace.Name), nil
+	}
+	return "", nil
+}
+
+// reconcilePaused reports whether the reconciliation of obj is paused, in which case its Paused condition is set.
+// The condition is reset by setSyncConditions once the reconciliation resumes.
+func reconcilePaused(ctx context.Context, c client.Client, recorder record.EventRecorder, obj syncStatusObject) (bool, error) {
+	reason, err := pausedReason(ctx, c, obj)
+	if err != nil || reason == "" {
+		return false, err
+	}
+
+	syncStatus := obj.GetSyncStatus()
+	if meta.IsStatusConditionTrue(syncStatus.Conditions, coralogixv1alpha1.ConditionTypePaused) {
+		return true, nil
+	}
+	meta.SetStatusCondition(&syncStatus.Conditions, metav1.Condition{
+		Type:               coralogixv1alpha1.ConditionTypePaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             coralogixv1alpha1.ReasonPaused,
+		Message:            reason,
+	})
+	if err = c.Status().Update(ctx, obj); err != nil {
+		return true, fmt.Errorf("error on updating paused status: %w", err)
+	}
+	recorder.Event(obj, corev1.EventTypeNormal, ReasonPaused, reason)
+	return true, nil
+}
+
+// namespaceLabelChangedPredicate passes the namespaces whose labels changed, which may pause or resume their resources.
+var namespaceLabelChangedPredicate = predicate.And(
+	predicate.LabelChangedPredicate{},
+	predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		DeleteFunc: func(event.DeleteEvent) bool { return false },
+	},
+)
+
+// enqueueNamespaceObjects enqueues the resources in a namespace, listed with newList, so pausing or resuming the
+// namespace applies to them right away.
+func enqueueNamespaceObjects(c client.Client, newList func() client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, namespace client.Object) []reconcile.Request {
+		list := newList()
+		if err := c.List(ctx, list, client.InNamespace(namespace.GetName())); err != nil {
+			log.FromContext(ctx).Error(err, "Error on listing resources of namespace", "namespace", namespace.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		_ = meta.EachListItem(list, func(obj runtime.Object) error {
+			if obj, ok := obj.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+			}
+			return nil
+		})
+		return requests
+	})
+}
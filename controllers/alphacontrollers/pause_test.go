@@ -0,0 +1,95 @@
+package alphacontrollers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+func TestReconcilePaused(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		namespaceLabels map[string]string
+		paused          bool
+	}{
+		{
+			name: "not paused",
+		},
+		{
+			name:        "paused by annotation",
+			annotations: map[string]string{coralogixv1alpha1.ReconcileAnnotation: coralogixv1alpha1.ReconcilePaused},
+			paused:      true,
+		},
+		{
+			name:            "paused by namespace label",
+			namespaceLabels: map[string]string{coralogixv1alpha1.ReconcileAnnotation: coralogixv1alpha1.ReconcilePaused},
+			paused:          true,
+		},
+		{
+			name:        "other annotation value",
+			annotations: map[string]string{coralogixv1alpha1.ReconcileAnnotation: "enabled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+			utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+
+			webhook := &coralogixv1alpha1.OutboundWebhook{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: "monitoring", Annotations: tt.annotations},
+			}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Labels: tt.namespaceLabels}}
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(webhook, namespace).
+				WithStatusSubresource(webhook).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+
+			paused, err := reconcilePaused(context.Background(), c, recorder, webhook)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.paused, paused)
+
+			latest := &coralogixv1alpha1.OutboundWebhook{}
+			assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(webhook), latest))
+			assert.Equal(t, tt.paused, meta.IsStatusConditionTrue(latest.Status.Conditions, coralogixv1alpha1.ConditionTypePaused))
+			assert.Equal(t, tt.paused, len(recorder.Events) == 1)
+
+			// An already paused resource is neither updated nor recorded again.
+			paused, err = reconcilePaused(context.Background(), c, recorder, latest)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.paused, paused)
+			assert.Equal(t, tt.paused, len(recorder.Events) == 1)
+		})
+	}
+}
+
+func TestSetSyncConditionsResumes(t *testing.T) {
+	syncStatus := coralogixv1alpha1.SyncStatus{
+		Conditions: []metav1.Condition{{
+			Type:   coralogixv1alpha1.ConditionTypePaused,
+			Status: metav1.ConditionTrue,
+			Reason: coralogixv1alpha1.ReasonPaused,
+		}},
+	}
+
+	setSyncConditions(&syncStatus, 1, nil)
+
+	paused := meta.FindStatusCondition(syncStatus.Conditions, coralogixv1alpha1.ConditionTypePaused)
+	assert.Equal(t, metav1.ConditionFalse, paused.Status)
+	assert.Equal(t, coralogixv1alpha1.ReasonResumed, paused.Reason)
+}
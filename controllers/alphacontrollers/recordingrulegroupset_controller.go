@@ -19,14 +19,20 @@ package alphacontrollers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -42,22 +48,34 @@ var recordingRuleGroupSetFinalizerName = "recordingrulegroupset.coralogix.com/fi
 type RecordingRuleGroupSetReconciler struct {
 	client.Client
 	CoralogixClientSet          clientset.ClientSetInterface
+	Accounts                    ClientSetResolver
 	Scheme                      *runtime.Scheme
 	RecordingRuleGroupSetSuffix string
+	// ResyncPeriod is how often the remote recording rule groupSet is compared with the spec, to catch out-of-band changes.
+	// Zero disables the periodic resync.
+	ResyncPeriod time.Duration
+	Recorder     record.EventRecorder
+	// MaxConcurrentReconciles is the number of resources reconciled in parallel. Zero means one.
+	MaxConcurrentReconciles int
+	// DeletionPolicy is what happens to the remote recording rule groupSet of a deleted resource without a deletion policy.
+	// Empty means Delete.
+	DeletionPolicy coralogixv1alpha1.DeletionPolicy
+	// DryRun records the changes that would be made to the remote recording rule groupSets in the status and events, instead of making them.
+	DryRun bool
+	// WatchFilter restricts the reconciled recording rule groupSets to some namespaces and labels. The zero value reconciles all of them.
+	WatchFilter WatchFilter
 }
 
 //+kubebuilder:rbac:groups=coralogix.com,resources=recordingrulegroupsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=coralogix.com,resources=recordingrulegroupsets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=coralogix.com,resources=recordingrulegroupsets/finalizers,verbs=update
 
-func (r *RecordingRuleGroupSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *RecordingRuleGroupSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := log.FromContext(ctx).WithValues(
 		"recordingRuleGroupSet", req.NamespacedName.Name,
 		"namespace", req.NamespacedName.Namespace,
 	)
 
-	// recordingRuleClient := r.CoralogixClientSet.RecordingRuleGroups()
-
 	recordingRuleGroupSet := &coralogixv1alpha1.RecordingRuleGroupSet{}
 	if err := r.Client.Get(ctx, req.NamespacedName, recordingRuleGroupSet); err != nil {
 		if errors.IsNotFound(err) {
@@ -70,43 +88,96 @@ func (r *RecordingRuleGroupSetReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 	}
 
+	if paused, pauseErr := reconcilePaused(ctx, r.Client, r.Recorder, recordingRuleGroupSet); pauseErr != nil {
+		log.Error(pauseErr, "Error on checking whether the recording rule groupSet reconciliation is paused")
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, pauseErr
+	} else if paused {
+		// Neither changes nor deletions are propagated until the reconciliation is resumed.
+		log.V(1).Info("Recording rule groupSet reconciliation is paused")
+		return ctrl.Result{}, nil
+	}
+
+	// The plan is recomputed on each reconciliation.
+	recordingRuleGroupSet.Status.Plan = nil
+	defer func() {
+		if statusErr := updateSyncStatus(ctx, r.Client, recordingRuleGroupSet, r.DryRun, err); statusErr != nil {
+			log.Error(statusErr, "Failed to update RecordingRuleGroupSet sync status")
+			if err == nil {
+				result, err = ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, statusErr
+			}
+		}
+	}()
+
+	clientSet, err := resolveClientSet(ctx, r.Accounts, r.CoralogixClientSet, recordingRuleGroupSet.Namespace, recordingRuleGroupSet.Spec.AccountRef)
+	if err != nil {
+		var released bool
+		if released, err = releaseWithoutAccount(ctx, r.Client, r.Recorder, recordingRuleGroupSet, recordingRuleGroupSetFinalizerName, "recording rule groupSet", err); released {
+			log.Info("Account of the RecordingRuleGroupSet was deleted, leaving the remote recording rule groupSet in Coralogix")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to resolve Coralogix account", "error", err)
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+
 	if ptr.Deref(recordingRuleGroupSet.Status.ID, "") == "" {
-		if err := r.create(ctx, recordingRuleGroupSet); err != nil {
+		id, err := pendingImportID(ctx, r.Client, recordingRuleGroupSet, &coralogixv1alpha1.RecordingRuleGroupSetList{})
+		if err != nil {
+			recordInvalidSpec(r.Recorder, recordingRuleGroupSet, "recording rule groupSet", err)
+			log.Error(err, "Failed to check the RecordingRuleGroupSet to import")
+			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+		}
+		if id != "" {
+			if err := r.importRemote(ctx, clientSet, recordingRuleGroupSet, id); err != nil {
+				log.Error(err, "Failed to import RecordingRuleGroupSet", "id", id)
+				return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+			}
+			// The imported recording rule groupSet is reconciled to the spec right away.
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		if err := r.create(ctx, clientSet, recordingRuleGroupSet); err != nil {
 			log.Error(err, "Failed to create RecordingRuleGroupSet", "error", err)
 			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 		}
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
 	}
 
 	if !recordingRuleGroupSet.ObjectMeta.DeletionTimestamp.IsZero() {
-		if err := r.delete(ctx, recordingRuleGroupSet); err != nil {
+		if err := r.delete(ctx, clientSet, recordingRuleGroupSet); err != nil {
 			log.Error(err, "Failed to delete RecordingRuleGroupSet", "error", err)
 			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 		}
 		return ctrl.Result{}, nil
 	}
 
-	if err := r.update(ctx, recordingRuleGroupSet); err != nil {
+	if err := r.update(ctx, clientSet, recordingRuleGroupSet); err != nil {
 		log.Error(err, "Failed to update RecordingRuleGroupSet", "error", err)
 		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
 }
 
-func (r *RecordingRuleGroupSetReconciler) create(ctx context.Context, recordingRuleGroupSet *coralogixv1alpha1.RecordingRuleGroupSet) error {
-	response, err := r.CoralogixClientSet.
-		RecordingRuleGroups().
-		Create(ctx, &cxsdk.CreateRuleGroupSetRequest{
-			Name:   ptr.To(fmt.Sprintf("%s%s", recordingRuleGroupSet.Name, r.RecordingRuleGroupSetSuffix)),
-			Groups: recordingRuleGroupSet.Spec.ExtractRecordingRuleGroups(),
-		})
+func (r *RecordingRuleGroupSetReconciler) create(ctx context.Context, clientSet clientset.ClientSetInterface, recordingRuleGroupSet *coralogixv1alpha1.RecordingRuleGroupSet) error {
+	createRequest := &cxsdk.CreateRuleGroupSetRequest{
+		Name:   ptr.To(fmt.Sprintf("%s%s", recordingRuleGroupSet.Name, r.RecordingRuleGroupSetSuffix)),
+		Groups: recordingRuleGroupSet.Spec.ExtractRecordingRuleGroups(),
+	}
+	if r.DryRun {
+		log.FromContext(ctx).Info("Dry run: not creating recording rule groupSet")
+		recordPlan(r.Recorder, recordingRuleGroupSet, "recording rule groupSet", coralogixv1alpha1.PlanActionCreate, "", createRequest)
+		return nil
+	}
 
+	response, err := clientSet.RecordingRuleGroups().Create(ctx, createRequest)
 	if err != nil {
 		return fmt.Errorf("failed to create recording rule groupSet: %w", err)
 	}
 
+	r.Recorder.Eventf(recordingRuleGroupSet, corev1.EventTypeNormal, ReasonCreated, "Remote recording rule groupSet %s was created", response.Id)
+
 	recordingRuleGroupSet.Status.ID = ptr.To(response.Id)
+	recordingRuleGroupSet.Status.Groups = recordingRuleGroupSet.Spec.Groups
 
 	if err := r.Status().Update(ctx, recordingRuleGroupSet); err != nil {
 		return fmt.Errorf("failed to update recording rule groupSet status: %w", err)
@@ -123,13 +194,40 @@ func (r *RecordingRuleGroupSetReconciler) create(ctx context.Context, recordingR
 	return nil
 }
 
-func (r *RecordingRuleGroupSetReconciler) update(ctx context.Context, recordingRuleGroupSet *coralogixv1alpha1.RecordingRuleGroupSet) error {
-	remoteRecordingRule, err := r.CoralogixClientSet.RecordingRuleGroups().Get(ctx, &cxsdk.GetRuleGroupSetRequest{
+// importRemote binds the recording rule groupSet to the existing remote one id, instead of creating one.
+func (r *RecordingRuleGroupSetReconciler) importRemote(ctx context.Context, clientSet clientset.ClientSetInterface, recordingRuleGroupSet *coralogixv1alpha1.RecordingRuleGroupSet, id string) error {
+	remoteRecordingRule, err := clientSet.RecordingRuleGroups().Get(ctx, &cxsdk.GetRuleGroupSetRequest{Id: id})
+	if err != nil {
+		return fmt.Errorf("failed to get recording rule groupSet %s to import: %w", id, err)
+	}
+
+	recordingRuleGroupSet.Status.ID = ptr.To(id)
+	recordingRuleGroupSet.Status.Groups = flattenRecordingRuleGroups(remoteRecordingRule.GetGroups())
+	recordingRuleGroupSet.Status.ImportedID = id
+	if err := r.Status().Update(ctx, recordingRuleGroupSet); err != nil {
+		return fmt.Errorf("failed to update recording rule groupSet status: %w", err)
+	}
+	r.Recorder.Eventf(recordingRuleGroupSet, corev1.EventTypeNormal, ReasonImported, "Remote recording rule groupSet %s was imported", id)
+
+	if !controllerutil.ContainsFinalizer(recordingRuleGroupSet, recordingRuleGroupSetFinalizerName) {
+		controllerutil.AddFinalizer(recordingRuleGroupSet, recordingRuleGroupSetFinalizerName)
+		if err := r.Client.Update(ctx, recordingRuleGroupSet); err != nil {
+			return fmt.Errorf("failed to update recording rule groupSet: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *RecordingRuleGroupSetReconciler) update(ctx context.Context, clientSet clientset.ClientSetInterface, recordingRuleGroupSet *coralogixv1alpha1.RecordingRuleGroupSet) error {
+	log := log.FromContext(ctx)
+	remoteRecordingRule, err := clientSet.RecordingRuleGroups().Get(ctx, &cxsdk.GetRuleGroupSetRequest{
 		Id: *recordingRuleGroupSet.Status.ID,
 	})
 
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
+			r.Recorder.Eventf(recordingRuleGroupSet, corev1.EventTypeWarning, ReasonRemoteNotFound, "Remote recording rule groupSet %s was not found, recreating it", *recordingRuleGroupSet.Status.ID)
 			recordingRuleGroupSet.Status.ID = nil
 			if err := r.Status().Update(ctx, recordingRuleGroupSet); err != nil {
 				return fmt.Errorf("failed to update recording rule groupSet status: %w", err)
@@ -139,29 +237,112 @@ func (r *RecordingRuleGroupSetReconciler) update(ctx context.Context, recordingR
 		return fmt.Errorf("failed to get recording rule groupSet: %w", err)
 	}
 
-	if _, err := r.CoralogixClientSet.
-		RecordingRuleGroups().
-		Update(ctx, &cxsdk.UpdateRuleGroupSetRequest{
-			Id:     remoteRecordingRule.Id,
-			Groups: recordingRuleGroupSet.Spec.ExtractRecordingRuleGroups(),
-		}); err != nil {
+	actualStatus := coralogixv1alpha1.RecordingRuleGroupSetStatus{
+		ID:         recordingRuleGroupSet.Status.ID,
+		Groups:     flattenRecordingRuleGroups(remoteRecordingRule.GetGroups()),
+		SyncStatus: recordingRuleGroupSet.Status.SyncStatus,
+	}
+
+	equal, diff := recordingRuleGroupSet.Spec.DeepEqual(actualStatus)
+	if equal {
+		actualStatus.LastDrift = resolvedDrift(recordingRuleGroupSet.Status.LastDrift)
+		if equality.Semantic.DeepEqual(recordingRuleGroupSet.Status, actualStatus) {
+			return nil
+		}
+		recordingRuleGroupSet.Status = actualStatus
+		if err := r.Status().Update(ctx, recordingRuleGroupSet); err != nil {
+			return fmt.Errorf("failed to update recording rule groupSet status: %w", err)
+		}
+		return nil
+	}
+	log.V(1).Info("Found diff between spec and remote recording rule groupSet", "diff", diff)
+
+	// The spec was applied already, so the remote recording rule groupSet was changed outside of the operator.
+	if specApplied, _ := recordingRuleGroupSet.Spec.DeepEqual(recordingRuleGroupSet.Status); specApplied {
+		drift := coralogixv1alpha1.NewDrift(diff, recordingRuleGroupSet.Spec.DriftPolicy.Enforces())
+		if !drift.Enforced {
+			log.Info("Remote recording rule groupSet drifted from the spec, reporting it only", "field", drift.Field)
+			if drift.SameAs(recordingRuleGroupSet.Status.LastDrift) {
+				return nil
+			}
+			recordDrift(r.Recorder, recordingRuleGroupSet, "recording rule groupSet", drift)
+			recordingRuleGroupSet.Status.LastDrift = drift
+			if err := r.Status().Update(ctx, recordingRuleGroupSet); err != nil {
+				return fmt.Errorf("failed to update recording rule groupSet status: %w", err)
+			}
+			return nil
+		}
+		recordDrift(r.Recorder, recordingRuleGroupSet, "recording rule groupSet", drift)
+	}
+
+	updateRequest := &cxsdk.UpdateRuleGroupSetRequest{
+		Id:     remoteRecordingRule.Id,
+		Groups: recordingRuleGroupSet.Spec.ExtractRecordingRuleGroups(),
+	}
+	if r.DryRun {
+		log.Info("Dry run: not updating recording rule groupSet", "id", remoteRecordingRule.Id, "field", diff.Name)
+		recordPlan(r.Recorder, recordingRuleGroupSet, "recording rule groupSet", coralogixv1alpha1.PlanActionUpdate, diff.Name, updateRequest)
+		return nil
+	}
+
+	if _, err := clientSet.RecordingRuleGroups().Update(ctx, updateRequest); err != nil {
 		return fmt.Errorf("failed to update recording rule groupSet: %w", err)
 	}
+	r.Recorder.Eventf(recordingRuleGroupSet, corev1.EventTypeNormal, ReasonUpdated, "Remote recording rule groupSet %s was updated", remoteRecordingRule.Id)
+
+	recordingRuleGroupSet.Status.Groups = recordingRuleGroupSet.Spec.Groups
+	recordingRuleGroupSet.Status.LastDrift = coralogixv1alpha1.NewDrift(diff, true)
+	if err := r.Status().Update(ctx, recordingRuleGroupSet); err != nil {
+		return fmt.Errorf("failed to update recording rule groupSet status: %w", err)
+	}
 
 	return nil
 }
 
-func (r *RecordingRuleGroupSetReconciler) delete(ctx context.Context, recordingRuleGroupSet *coralogixv1alpha1.RecordingRuleGroupSet) error {
-	_, err := r.CoralogixClientSet.RecordingRuleGroups().Delete(ctx, &cxsdk.DeleteRuleGroupSetRequest{
-		Id: *recordingRuleGroupSet.Status.ID,
-	})
+func flattenRecordingRuleGroups(groups []*cxsdk.OutRuleGroup) []coralogixv1alpha1.RecordingRuleGroup {
+	result := make([]coralogixv1alpha1.RecordingRuleGroup, 0, len(groups))
+	for _, group := range groups {
+		rules := make([]coralogixv1alpha1.RecordingRule, 0, len(group.GetRules()))
+		for _, rule := range group.GetRules() {
+			recordingRule := coralogixv1alpha1.RecordingRule{
+				Record: rule.GetRecord(),
+				Expr:   rule.GetExpr(),
+			}
+			if len(rule.GetLabels()) > 0 {
+				recordingRule.Labels = rule.GetLabels()
+			}
+			rules = append(rules, recordingRule)
+		}
+		result = append(result, coralogixv1alpha1.RecordingRuleGroup{
+			Name:            group.GetName(),
+			IntervalSeconds: int32(group.GetInterval()),
+			Limit:           int64(group.GetLimit()),
+			Rules:           rules,
+		})
+	}
+	return result
+}
+
+func (r *RecordingRuleGroupSetReconciler) delete(ctx context.Context, clientSet clientset.ClientSetInterface, recordingRuleGroupSet *coralogixv1alpha1.RecordingRuleGroupSet) error {
+	if recordingRuleGroupSet.Spec.DeletionPolicy.Retains(r.DeletionPolicy) {
+		r.Recorder.Eventf(recordingRuleGroupSet, corev1.EventTypeNormal, ReasonRetained, "Remote recording rule groupSet %s was retained", *recordingRuleGroupSet.Status.ID)
+	} else if r.DryRun {
+		// The resource is not kept until the operator leaves dry-run mode, so the remote recording rule groupSet is left as is.
+		log.FromContext(ctx).Info("Dry run: not deleting recording rule groupSet", "id", *recordingRuleGroupSet.Status.ID)
+		recordPlan(r.Recorder, recordingRuleGroupSet, "recording rule groupSet", coralogixv1alpha1.PlanActionDelete, "", nil)
+	} else {
+		_, err := clientSet.RecordingRuleGroups().Delete(ctx, &cxsdk.DeleteRuleGroupSetRequest{
+			Id: *recordingRuleGroupSet.Status.ID,
+		})
 
-	if err != nil && status.Code(err) != codes.NotFound {
-		return fmt.Errorf("failed to delete recording rule groupSet: %w", err)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("failed to delete recording rule groupSet: %w", err)
+		}
+		r.Recorder.Eventf(recordingRuleGroupSet, corev1.EventTypeNormal, ReasonDeleted, "Remote recording rule groupSet %s was deleted", *recordingRuleGroupSet.Status.ID)
 	}
 
 	controllerutil.RemoveFinalizer(recordingRuleGroupSet, recordingRuleGroupSetFinalizerName)
-	if err = r.Update(ctx, recordingRuleGroupSet); err != nil {
+	if err := r.Update(ctx, recordingRuleGroupSet); err != nil {
 		return fmt.Errorf("failed to remove finalizer from recording rule groupSet: %w", err)
 	}
 
@@ -170,6 +351,9 @@ func (r *RecordingRuleGroupSetReconciler) delete(ctx context.Context, recordingR
 
 func (r *RecordingRuleGroupSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&coralogixv1alpha1.RecordingRuleGroupSet{}).
+		For(&coralogixv1alpha1.RecordingRuleGroupSet{}, builder.WithPredicates(reconcilePredicate, r.WatchFilter.Predicate())).
+		Watches(&corev1.Namespace{}, enqueueNamespaceObjects(mgr.GetClient(), func() client.ObjectList { return &coralogixv1alpha1.RecordingRuleGroupSetList{} }),
+			builder.WithPredicates(namespaceLabelChangedPredicate, r.WatchFilter.NamespacePredicate())).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
@@ -45,6 +45,7 @@ func setupRecordingRuleReconciler(t *testing.T, ctx context.Context, clientSet *
 	r := RecordingRuleGroupSetReconciler{
 		Client:             withWatch,
 		Scheme:             mgr.GetScheme(),
+		Recorder:           mgr.GetEventRecorderFor("coralogix-operator"),
 		CoralogixClientSet: clientSet,
 	}
 	r.SetupWithManager(mgr)
@@ -170,7 +171,8 @@ func TestRecordingRuleUpdate(t *testing.T) {
 						},
 					},
 				}, nil)
-				params.recordingRuleClient.EXPECT().Update(params.ctx, gomock.Any()).Return(&emptypb.Empty{}, nil)
+				// The remote recording rule groupSet matches the spec, so it isn't updated.
+				params.recordingRuleClient.EXPECT().Update(params.ctx, gomock.Any()).Times(0)
 			},
 			recordingRule: coralogixv1alpha1.RecordingRuleGroupSet{
 				ObjectMeta: metav1.ObjectMeta{
@@ -195,6 +197,53 @@ func TestRecordingRuleUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "Recording rule update when the remote differs",
+			shouldFail: false,
+			params: func(params PrepareRecordingRulesParams) {
+				params.recordingRuleClient.EXPECT().Create(params.ctx, gomock.Any()).Return(&cxsdk.CreateRuleGroupSetResponse{Id: "id1"}, nil)
+				params.recordingRuleClient.EXPECT().Get(params.ctx, gomock.Any()).Return(&cxsdk.GetRuleGroupSetResponse{
+					Id: "id1",
+					Groups: []*cxsdk.OutRuleGroup{
+						{
+							Name:     "name",
+							Interval: pointer.Uint32(60),
+							Limit:    pointer.Uint64(50),
+							Rules: []*cxsdk.OutRule{
+								{
+									Record: "record",
+									Expr:   "vector(1)",
+									Labels: map[string]string{"key": "value"},
+								},
+							},
+						},
+					},
+				}, nil)
+				params.recordingRuleClient.EXPECT().Update(params.ctx, gomock.Any()).Return(&emptypb.Empty{}, nil).Times(1)
+			},
+			recordingRule: coralogixv1alpha1.RecordingRuleGroupSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "recording-rule-update-remote-differs",
+					Namespace: "default",
+				},
+				Spec: coralogixv1alpha1.RecordingRuleGroupSetSpec{
+					Groups: []coralogixv1alpha1.RecordingRuleGroup{
+						{
+							Name:            "name",
+							IntervalSeconds: 60,
+							Limit:           100,
+							Rules: []coralogixv1alpha1.RecordingRule{
+								{
+									Record: "record",
+									Expr:   "vector(1)",
+									Labels: map[string]string{"key": "value"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
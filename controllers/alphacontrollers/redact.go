@@ -0,0 +1,100 @@
+package alphacontrollers
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+// sensitiveProtoFields are the fields of the Coralogix API messages holding credentials, at any depth: the tokens and
+// keys of outbound webhooks, the headers of generic webhooks, and their URLs, which often embed a key.
+var sensitiveProtoFields = map[protoreflect.Name]bool{
+	"api_token":   true,
+	"service_key": true,
+	"headers":     true,
+	"url":         true,
+}
+
+var stringValueName = (&wrapperspb.StringValue{}).ProtoReflect().Descriptor().FullName()
+
+// redactMessage returns a copy of msg with the values of its sensitive fields replaced with their hashes, to be logged
+// or recorded in plans instead of msg.
+func redactMessage[M proto.Message](msg M) M {
+	if !msg.ProtoReflect().IsValid() {
+		return msg
+	}
+	redacted := proto.Clone(msg).(M)
+	redactFields(redacted.ProtoReflect())
+	return redacted
+}
+
+// redactedJSON formats msg with its sensitive fields redacted, for logs and errors.
+func redactedJSON(msg proto.Message) string {
+	return protojson.Format(redactMessage(msg))
+}
+
+func redactFields(m protoreflect.Message) {
+	// The fields are set after ranging over them, as the message can't be changed while ranging.
+	var hashed []func()
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case sensitiveProtoFields[fd.Name()]:
+			if set := hashField(m, fd, v); set != nil {
+				hashed = append(hashed, set)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					redactFields(value.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					redactFields(v.List().Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			redactFields(v.Message())
+		}
+		return true
+	})
+	for _, set := range hashed {
+		set()
+	}
+}
+
+// hashField returns the function setting the sensitive field fd of m to the hash of its value v, or nil if fd holds
+// neither a string, a wrapped string nor a map of strings.
+func hashField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) func() {
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Kind() != protoreflect.StringKind {
+			return nil
+		}
+		return func() {
+			values := v.Map()
+			values.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				values.Set(key, protoreflect.ValueOfString(coralogixv1alpha1.HashSecret(value.String())))
+				return true
+			})
+		}
+	case fd.IsList():
+		return nil
+	case fd.Kind() == protoreflect.StringKind:
+		return func() {
+			m.Set(fd, protoreflect.ValueOfString(coralogixv1alpha1.HashSecret(v.String())))
+		}
+	case fd.Message() != nil && fd.Message().FullName() == stringValueName:
+		return func() {
+			value := v.Message()
+			valueField := value.Descriptor().Fields().ByName("value")
+			value.Set(valueField, protoreflect.ValueOfString(coralogixv1alpha1.HashSecret(value.Get(valueField).String())))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package alphacontrollers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+func TestRedactMessage(t *testing.T) {
+	request := &cxsdk.CreateOutgoingWebhookRequest{Data: &cxsdk.OutgoingWebhookInputData{
+		Name: wrapperspb.String("generic"),
+		Type: cxsdk.WebhookTypeGeneric,
+		Url:  wrapperspb.String("https://example.com/hook?key=secret"),
+		Config: &cxsdk.GenericWebhookInputData{GenericWebhook: &cxsdk.GenericWebhookConfig{
+			Uuid:    wrapperspb.String("uuid"),
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		}},
+	}}
+
+	redacted := redactMessage(request)
+	assert.Equal(t, coralogixv1alpha1.HashSecret("https://example.com/hook?key=secret"), redacted.GetData().GetUrl().GetValue())
+	assert.Equal(t, map[string]string{"Authorization": coralogixv1alpha1.HashSecret("Bearer secret")},
+		redacted.GetData().GetGenericWebhook().GetHeaders())
+	assert.Equal(t, "generic", redacted.GetData().GetName().GetValue())
+	assert.Equal(t, "uuid", redacted.GetData().GetGenericWebhook().GetUuid().GetValue())
+
+	assert.Equal(t, "https://example.com/hook?key=secret", request.GetData().GetUrl().GetValue(), "the message should not be changed")
+	assert.NotContains(t, redactedJSON(request), "secret")
+
+	jira := redactMessage(&cxsdk.OutgoingWebhookInputData{Config: &cxsdk.JiraWebhookInputData{Jira: &cxsdk.JiraConfig{
+		ApiToken: wrapperspb.String("token"),
+		Email:    wrapperspb.String("jira@example.com"),
+	}}})
+	assert.Equal(t, coralogixv1alpha1.HashSecret("token"), jira.GetJira().GetApiToken().GetValue())
+	assert.Equal(t, "jira@example.com", jira.GetJira().GetEmail().GetValue())
+
+	assert.Nil(t, redactMessage((*cxsdk.CreateOutgoingWebhookRequest)(nil)))
+}
@@ -0,0 +1,46 @@
+package alphacontrollers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coralogix/coralogix-operator/controllers/overrides"
+)
+
+// ResyncKinds are the kinds whose remote objects are periodically compared with their spec.
+var ResyncKinds = []string{"Alert", "RuleGroup", "RecordingRuleGroupSet", "OutboundWebhook"}
+
+// ResyncPeriods holds the resync period of each kind.
+type ResyncPeriods struct {
+	Default time.Duration
+	ByKind  map[string]time.Duration
+}
+
+// For returns the resync period of kind, falling back to the default one.
+func (p ResyncPeriods) For(kind string) time.Duration {
+	if period, ok := p.ByKind[kind]; ok {
+		return period
+	}
+	return p.Default
+}
+
+// ParseResyncPeriods parses a comma separated list of a default duration and <Kind>=<duration> overrides,
+// e.g. "10m,Alert=5m,OutboundWebhook=0". A zero duration disables the resync.
+func ParseResyncPeriods(value string) (ResyncPeriods, error) {
+	defaultPeriod, byKind, err := overrides.Parse(value, "resync period", "kind", ResyncKinds, parseResyncPeriod)
+	if err != nil {
+		return ResyncPeriods{}, err
+	}
+	return ResyncPeriods{Default: defaultPeriod, ByKind: byKind}, nil
+}
+
+func parseResyncPeriod(value string) (time.Duration, error) {
+	period, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if period < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return period, nil
+}
@@ -0,0 +1,72 @@
+package alphacontrollers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResyncPeriods(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		want       map[string]time.Duration
+		shouldFail bool
+	}{
+		{
+			name:  "empty value disables the resync",
+			value: "",
+			want:  map[string]time.Duration{"Alert": 0, "RuleGroup": 0},
+		},
+		{
+			name:  "single duration applies to all kinds",
+			value: "10m",
+			want:  map[string]time.Duration{"Alert": 10 * time.Minute, "OutboundWebhook": 10 * time.Minute},
+		},
+		{
+			name:  "overrides per kind",
+			value: "10m, Alert=5m,OutboundWebhook=0",
+			want: map[string]time.Duration{
+				"Alert":                 5 * time.Minute,
+				"OutboundWebhook":       0,
+				"RuleGroup":             10 * time.Minute,
+				"RecordingRuleGroupSet": 10 * time.Minute,
+			},
+		},
+		{
+			name:  "overrides only",
+			value: "RuleGroup=1h",
+			want:  map[string]time.Duration{"RuleGroup": time.Hour, "Alert": 0},
+		},
+		{
+			name:       "unknown kind",
+			value:      "Dashboard=5m",
+			shouldFail: true,
+		},
+		{
+			name:       "invalid duration",
+			value:      "Alert=5",
+			shouldFail: true,
+		},
+		{
+			name:       "negative duration",
+			value:      "-1m",
+			shouldFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods, err := ParseResyncPeriods(tt.value)
+			if tt.shouldFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			for kind, want := range tt.want {
+				assert.Equal(t, want, periods.For(kind), kind)
+			}
+		})
+	}
+}
@@ -19,16 +19,21 @@ package alphacontrollers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
 	"github.com/coralogix/coralogix-operator/controllers/clientset"
 
 	"github.com/golang/protobuf/jsonpb"
 	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -43,7 +48,21 @@ var ruleGroupFinalizerName = "rulegroup.coralogix.com/finalizer"
 type RuleGroupReconciler struct {
 	client.Client
 	CoralogixClientSet clientset.ClientSetInterface
+	Accounts           ClientSetResolver
 	Scheme             *runtime.Scheme
+	// ResyncPeriod is how often the remote rule group is compared with the spec, to catch out-of-band changes.
+	// Zero disables the periodic resync.
+	ResyncPeriod time.Duration
+	Recorder     record.EventRecorder
+	// MaxConcurrentReconciles is the number of resources reconciled in parallel. Zero means one.
+	MaxConcurrentReconciles int
+	// DeletionPolicy is what happens to the remote rule group of a deleted resource without a deletion policy.
+	// Empty means Delete.
+	DeletionPolicy coralogixv1alpha1.DeletionPolicy
+	// DryRun records the changes that would be made to the remote rule groups in the status and events, instead of making them.
+	DryRun bool
+	// WatchFilter restricts the reconciled rule groups to some namespaces and labels. The zero value reconciles all of them.
+	WatchFilter WatchFilter
 }
 
 //+kubebuilder:rbac:groups=coralogix.com,resources=rulegroups,verbs=get;list;watch;create;update;patch;delete
@@ -59,12 +78,11 @@ type RuleGroupReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.13.0/pkg/reconcile
-func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := log.FromContext(ctx)
 	jsm := &jsonpb.Marshaler{
 		EmitDefaults: true,
 	}
-	rulesGroupsClient := r.CoralogixClientSet.RuleGroups()
 
 	//Get ruleGroupCRD
 	ruleGroupCRD := &coralogixv1alpha1.RuleGroup{}
@@ -80,6 +98,38 @@ func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 	}
 
+	if paused, pauseErr := reconcilePaused(ctx, r.Client, r.Recorder, ruleGroupCRD); pauseErr != nil {
+		log.Error(pauseErr, "Error on checking whether the Rule-Group reconciliation is paused")
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, pauseErr
+	} else if paused {
+		// Neither changes nor deletions are propagated until the reconciliation is resumed.
+		log.V(1).Info("Rule-Group reconciliation is paused")
+		return ctrl.Result{}, nil
+	}
+
+	// The plan is recomputed on each reconciliation.
+	ruleGroupCRD.Status.Plan = nil
+	defer func() {
+		if statusErr := updateSyncStatus(ctx, r.Client, ruleGroupCRD, r.DryRun, err); statusErr != nil {
+			log.Error(statusErr, "Error on updating RuleGroup sync status", "Name", ruleGroupCRD.Name, "Namespace", ruleGroupCRD.Namespace)
+			if err == nil {
+				result, err = ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, statusErr
+			}
+		}
+	}()
+
+	clientSet, err := resolveClientSet(ctx, r.Accounts, r.CoralogixClientSet, ruleGroupCRD.Namespace, ruleGroupCRD.Spec.AccountRef)
+	if err != nil {
+		var released bool
+		if released, err = releaseWithoutAccount(ctx, r.Client, r.Recorder, ruleGroupCRD, ruleGroupFinalizerName, "rule group", err); released {
+			log.Info("Account of the Rule-Group was deleted, leaving the remote rule group in Coralogix")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Received an error while resolving the Coralogix account")
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+	rulesGroupsClient := clientSet.RuleGroups()
+
 	// examine DeletionTimestamp to determine if object is under deletion
 	if ruleGroupCRD.ObjectMeta.DeletionTimestamp.IsZero() {
 		// The object is not being deleted, so if it does not have our finalizer,
@@ -102,6 +152,23 @@ func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			}
 
 			ruleGroupId := *ruleGroupCRD.Status.ID
+			if ruleGroupCRD.Spec.DeletionPolicy.Retains(r.DeletionPolicy) {
+				log.V(1).Info("Retaining Rule-Group", "Rule-Group ID", ruleGroupId)
+				r.Recorder.Eventf(ruleGroupCRD, corev1.EventTypeNormal, ReasonRetained, "Remote rule group %s was retained", ruleGroupId)
+				controllerutil.RemoveFinalizer(ruleGroupCRD, ruleGroupFinalizerName)
+				err := r.Update(ctx, ruleGroupCRD)
+				return ctrl.Result{}, err
+			}
+
+			if r.DryRun {
+				// The resource is not kept until the operator leaves dry-run mode, so the remote rule group is left as is.
+				log.Info("Dry run: not deleting Rule-Group", "Rule-Group ID", ruleGroupId)
+				recordPlan(r.Recorder, ruleGroupCRD, "rule group", coralogixv1alpha1.PlanActionDelete, "", nil)
+				controllerutil.RemoveFinalizer(ruleGroupCRD, ruleGroupFinalizerName)
+				err := r.Update(ctx, ruleGroupCRD)
+				return ctrl.Result{}, err
+			}
+
 			deleteRuleGroupReq := &cxsdk.DeleteRuleGroupRequest{GroupId: ruleGroupId}
 			log.V(1).Info("Deleting Rule-Group", "Rule-Group ID", ruleGroupId)
 			if _, err := rulesGroupsClient.Delete(ctx, deleteRuleGroupReq); err != nil {
@@ -117,6 +184,7 @@ func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			}
 
 			log.V(1).Info("Rule-Group was deleted", "Rule-Group ID", ruleGroupId)
+			r.Recorder.Eventf(ruleGroupCRD, corev1.EventTypeNormal, ReasonDeleted, "Remote rule group %s was deleted", ruleGroupId)
 			// remove our finalizer from the list and update it.
 			controllerutil.RemoveFinalizer(ruleGroupCRD, ruleGroupFinalizerName)
 			if err := r.Update(ctx, ruleGroupCRD); err != nil {
@@ -128,9 +196,25 @@ func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	if ruleGroupCRD.Status.ID == nil {
+		id, err := pendingImportID(ctx, r.Client, ruleGroupCRD, &coralogixv1alpha1.RuleGroupList{})
+		if err != nil {
+			recordInvalidSpec(r.Recorder, ruleGroupCRD, "rule group", err)
+			log.Error(err, "Received an error while checking the Rule-Group to import")
+			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+		}
+		if id != "" {
+			if err := r.importRemote(ctx, rulesGroupsClient, ruleGroupCRD, id); err != nil {
+				log.Error(err, "Received an error while importing a Rule-Group", "Rule-Group ID", id)
+				return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+			}
+			// The imported rule group is reconciled to the spec right away.
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
 	var (
 		notFound    bool
-		err         error
 		actualState *coralogixv1alpha1.RuleGroupStatus
 	)
 
@@ -142,6 +226,7 @@ func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		switch {
 		case status.Code(err) == codes.NotFound:
 			log.V(1).Info("ruleGroup doesn't exist in Coralogix backend")
+			r.Recorder.Eventf(ruleGroupCRD, corev1.EventTypeWarning, ReasonRemoteNotFound, "Remote rule group %s was not found, recreating it", *id)
 			notFound = true
 		case err != nil:
 			log.Error(err, "Received an error while getting RuleGroup")
@@ -157,15 +242,21 @@ func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	if notFound {
 		createRuleGroupReq := ruleGroupCRD.Spec.ExtractCreateRuleGroupRequest()
-		jstr, _ := jsm.MarshalToString(createRuleGroupReq)
+		if r.DryRun {
+			log.Info("Dry run: not creating Rule-Group")
+			recordPlan(r.Recorder, ruleGroupCRD, "rule group", coralogixv1alpha1.PlanActionCreate, "", createRuleGroupReq)
+			return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+		}
+		jstr, _ := jsm.MarshalToString(redactMessage(createRuleGroupReq))
 		log.V(1).Info("Creating Rule-Group", "ruleGroup", jstr)
 		if createRuleGroupResp, err := rulesGroupsClient.Create(ctx, createRuleGroupReq); err == nil {
-			jstr, _ := jsm.MarshalToString(createRuleGroupResp)
+			jstr, _ := jsm.MarshalToString(redactMessage(createRuleGroupResp))
 			log.V(1).Info("Rule-Group was updated", "ruleGroup", jstr)
 
 			//To avoid a situation of the operator falling between the creation of the ruleGroup in coralogix and being saved in the cluster (something that would cause it to be created again and again), its id will be saved ASAP.
 			id := createRuleGroupResp.GetRuleGroup().GetId().GetValue()
-			ruleGroupCRD.Status = coralogixv1alpha1.RuleGroupStatus{ID: &id}
+			r.Recorder.Eventf(ruleGroupCRD, corev1.EventTypeNormal, ReasonCreated, "Remote rule group %s was created", id)
+			ruleGroupCRD.Status = coralogixv1alpha1.RuleGroupStatus{ID: &id, SyncStatus: ruleGroupCRD.Status.SyncStatus}
 			if err := r.Status().Update(ctx, ruleGroupCRD); err != nil {
 				log.Error(err, "Error on updating RecordingRuleGroupSet status", "Name", ruleGroupCRD.Name, "Namespace", ruleGroupCRD.Namespace)
 				return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
@@ -176,13 +267,14 @@ func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				log.Error(err, "Error mapping coralogix API response", "Name", ruleGroupCRD.Name, "Namespace", ruleGroupCRD.Namespace)
 				return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 			}
+			status.SyncStatus = ruleGroupCRD.Status.SyncStatus
 			ruleGroupCRD.Status = *status
 			if err := r.Status().Update(ctx, ruleGroupCRD); err != nil {
 				log.V(1).Error(err, "updating crd")
 			}
-			return ctrl.Result{}, nil
+			return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
 		} else {
-			log.Error(err, "Received an error while creating a Rule-Group", "ruleGroup", createRuleGroupReq)
+			log.Error(err, "Received an error while creating a Rule-Group", "ruleGroup", redactMessage(createRuleGroupReq))
 			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 		}
 	} else if err != nil {
@@ -190,19 +282,89 @@ func (r *RuleGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 	}
 
-	if equal, diff := ruleGroupCRD.Spec.DeepEqual(*actualState); !equal {
-		log.V(1).Info("Find diffs between spec and the actual state", "Diff", diff)
-		updateRuleGroupReq := ruleGroupCRD.Spec.ExtractUpdateRuleGroupRequest(*ruleGroupCRD.Status.ID)
-		updateRuleGroupResp, err := rulesGroupsClient.Update(ctx, updateRuleGroupReq)
-		if err != nil {
-			log.Error(err, "Received an error while updating a Rule-Group", "ruleGroup", updateRuleGroupReq)
-			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	equal, diff := ruleGroupCRD.Spec.DeepEqual(*actualState)
+	if equal {
+		if lastDrift := resolvedDrift(ruleGroupCRD.Status.LastDrift); lastDrift != ruleGroupCRD.Status.LastDrift {
+			ruleGroupCRD.Status.LastDrift = lastDrift
+			if err := r.Status().Update(ctx, ruleGroupCRD); err != nil {
+				log.Error(err, "Error on updating RuleGroup status", "Name", ruleGroupCRD.Name, "Namespace", ruleGroupCRD.Namespace)
+				return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+			}
 		}
-		jstr, _ := jsm.MarshalToString(updateRuleGroupResp)
-		log.V(1).Info("Rule-Group was updated", "ruleGroup", jstr)
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+	}
+	log.V(1).Info("Find diffs between spec and the actual state", "Diff", diff)
+
+	// The spec was applied already, so the remote rule group was changed outside of the operator.
+	if specApplied, _ := ruleGroupCRD.Spec.DeepEqual(ruleGroupCRD.Status); specApplied {
+		drift := coralogixv1alpha1.NewDrift(diff, ruleGroupCRD.Spec.DriftPolicy.Enforces())
+		if !drift.Enforced {
+			log.Info("Rule-Group drifted from the spec, reporting it only", "field", drift.Field)
+			if !drift.SameAs(ruleGroupCRD.Status.LastDrift) {
+				recordDrift(r.Recorder, ruleGroupCRD, "rule group", drift)
+				ruleGroupCRD.Status.LastDrift = drift
+				if err := r.Status().Update(ctx, ruleGroupCRD); err != nil {
+					log.Error(err, "Error on updating RuleGroup status", "Name", ruleGroupCRD.Name, "Namespace", ruleGroupCRD.Namespace)
+					return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+				}
+			}
+			return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+		}
+		recordDrift(r.Recorder, ruleGroupCRD, "rule group", drift)
+	}
+
+	updateRuleGroupReq := ruleGroupCRD.Spec.ExtractUpdateRuleGroupRequest(*ruleGroupCRD.Status.ID)
+	if r.DryRun {
+		log.Info("Dry run: not updating Rule-Group", "Rule-Group ID", *ruleGroupCRD.Status.ID, "field", diff.Name)
+		recordPlan(r.Recorder, ruleGroupCRD, "rule group", coralogixv1alpha1.PlanActionUpdate, diff.Name, updateRuleGroupReq)
+		return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+	}
+	updateRuleGroupResp, err := rulesGroupsClient.Update(ctx, updateRuleGroupReq)
+	if err != nil {
+		log.Error(err, "Received an error while updating a Rule-Group", "ruleGroup", redactMessage(updateRuleGroupReq))
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+	jstr, _ := jsm.MarshalToString(redactMessage(updateRuleGroupResp))
+	log.V(1).Info("Rule-Group was updated", "ruleGroup", jstr)
+	r.Recorder.Eventf(ruleGroupCRD, corev1.EventTypeNormal, ReasonUpdated, "Remote rule group %s was updated", *ruleGroupCRD.Status.ID)
+
+	updatedState, err := flattenRuleGroup(updateRuleGroupResp.GetRuleGroup())
+	if err != nil {
+		log.Error(err, "Error mapping coralogix API response", "Name", ruleGroupCRD.Name, "Namespace", ruleGroupCRD.Namespace)
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+	updatedState.SyncStatus = ruleGroupCRD.Status.SyncStatus
+	updatedState.LastDrift = coralogixv1alpha1.NewDrift(diff, true)
+	ruleGroupCRD.Status = *updatedState
+	if err := r.Status().Update(ctx, ruleGroupCRD); err != nil {
+		log.Error(err, "Error on updating RuleGroup status", "Name", ruleGroupCRD.Name, "Namespace", ruleGroupCRD.Namespace)
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+
+	return ctrl.Result{RequeueAfter: r.ResyncPeriod}, nil
+}
+
+// importRemote binds the rule group to the existing remote rule group id, instead of creating one.
+func (r *RuleGroupReconciler) importRemote(ctx context.Context, rulesGroupsClient clientset.RuleGroupsClientInterface, ruleGroupCRD *coralogixv1alpha1.RuleGroup, id string) error {
+	log.FromContext(ctx).V(1).Info("Importing Rule-Group", "Rule-Group ID", id)
+	getRuleGroupResp, err := rulesGroupsClient.Get(ctx, &cxsdk.GetRuleGroupRequest{GroupId: id})
+	if err != nil {
+		return fmt.Errorf("error on getting rule group %s to import: %w", id, err)
+	}
+
+	status, err := flattenRuleGroup(getRuleGroupResp.GetRuleGroup())
+	if err != nil {
+		return fmt.Errorf("error on mapping coralogix API response: %w", err)
+	}
+	status.SyncStatus = ruleGroupCRD.Status.SyncStatus
+	status.ImportedID = id
+	ruleGroupCRD.Status = *status
+	if err := r.Status().Update(ctx, ruleGroupCRD); err != nil {
+		return fmt.Errorf("error on updating rule group status: %w", err)
 	}
+	r.Recorder.Eventf(ruleGroupCRD, corev1.EventTypeNormal, ReasonImported, "Remote rule group %s was imported", id)
 
-	return ctrl.Result{}, nil
+	return nil
 }
 
 func flattenRuleGroup(ruleGroup *cxsdk.RuleGroup) (*coralogixv1alpha1.RuleGroupStatus, error) {
@@ -385,6 +547,9 @@ func flattenRuleMatcher(ruleMatchers []*cxsdk.RuleMatcher) (applications, subsys
 // SetupWithManager sets up the controller with the Manager.
 func (r *RuleGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&coralogixv1alpha1.RuleGroup{}).
+		For(&coralogixv1alpha1.RuleGroup{}, builder.WithPredicates(reconcilePredicate, r.WatchFilter.Predicate())).
+		Watches(&corev1.Namespace{}, enqueueNamespaceObjects(mgr.GetClient(), func() client.ObjectList { return &coralogixv1alpha1.RuleGroupList{} }),
+			builder.WithPredicates(namespaceLabelChangedPredicate, r.WatchFilter.NamespacePredicate())).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
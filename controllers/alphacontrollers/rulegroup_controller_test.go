@@ -189,6 +189,7 @@ func TestRuleGroupReconciler_Reconcile(t *testing.T) {
 	r := RuleGroupReconciler{
 		Client:             withWatch,
 		Scheme:             mgr.GetScheme(),
+		Recorder:           mgr.GetEventRecorderFor("coralogix-operator"),
 		CoralogixClientSet: mockClientSet,
 	}
 	r.SetupWithManager(mgr)
@@ -252,6 +253,7 @@ func TestRuleGroupReconciler_Reconcile_5XX_StatusError(t *testing.T) {
 	r := RuleGroupReconciler{
 		Client:             withWatch,
 		Scheme:             mgr.GetScheme(),
+		Recorder:           mgr.GetEventRecorderFor("coralogix-operator"),
 		CoralogixClientSet: mockClientSet,
 	}
 	r.SetupWithManager(mgr)
@@ -1,9 +1,276 @@
 package alphacontrollers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
 )
 
 const (
 	defaultErrRequeuePeriod = 60 * time.Second
+
+	maxConditionMessageLength = 1024
+)
+
+// reconcilePredicate skips the events of status-only changes, which the reconcilers make themselves.
+var reconcilePredicate = predicate.Or(
+	predicate.GenerationChangedPredicate{},
+	predicate.AnnotationChangedPredicate{},
+	predicate.LabelChangedPredicate{},
 )
+
+// syncStatusObject is a resource that reports the outcome of its reconciliation in its status.
+type syncStatusObject interface {
+	client.Object
+	GetSyncStatus() *coralogixv1alpha1.SyncStatus
+}
+
+// ClientSetResolver resolves the clientset of the Coralogix account a resource refers to with spec.accountRef.
+type ClientSetResolver interface {
+	ClientSet(ctx context.Context, namespace string, ref *coralogixv1alpha1.AccountReference) (clientset.ClientSetInterface, error)
+}
+
+// resolveClientSet returns the clientset of the account referenced by ref, or defaultClientSet when ref is nil.
+func resolveClientSet(ctx context.Context, accounts ClientSetResolver, defaultClientSet clientset.ClientSetInterface,
+	namespace string, ref *coralogixv1alpha1.AccountReference) (clientset.ClientSetInterface, error) {
+	if ref == nil {
+		return defaultClientSet, nil
+	}
+	if accounts == nil {
+		return nil, fmt.Errorf("accountRef %s is set, but accounts are not enabled", ref.Name)
+	}
+	return accounts.ClientSet(ctx, namespace, ref)
+}
+
+// releaseWithoutAccount removes the finalizer of obj when it is being deleted, but err says the account it refers to
+// was deleted first. Its remote object can't be deleted anymore, so it is left in Coralogix instead of blocking the
+// deletion forever. Otherwise, or when the finalizer can't be removed, the error to report is returned.
+func releaseWithoutAccount(ctx context.Context, c client.Client, recorder record.EventRecorder, obj client.Object,
+	finalizer, kind string, err error) (bool, error) {
+	var accountNotFound *coralogixv1alpha1.AccountNotFoundError
+	if obj.GetDeletionTimestamp().IsZero() || !errors.As(err, &accountNotFound) {
+		return false, err
+	}
+
+	if patchErr := patchFinalizers(ctx, c, obj, controllerutil.RemoveFinalizer, finalizer); patchErr != nil {
+		return false, fmt.Errorf("error on removing the finalizer of %s without account: %w", kind, patchErr)
+	}
+	recorder.Eventf(obj, corev1.EventTypeWarning, ReasonAccountNotFound,
+		"%s %s was deleted, so the remote %s was left in Coralogix", accountNotFound.Kind, accountNotFound.Name, kind)
+	return true, nil
+}
+
+// patchFinalizers changes the finalizers of obj with change, e.g. controllerutil.AddFinalizer, and patches only them,
+// so nothing else of the in-memory object, such as a defaulted spec, is persisted.
+func patchFinalizers(ctx context.Context, c client.Client, obj client.Object,
+	change func(client.Object, string) bool, finalizer string) error {
+	original := obj.DeepCopyObject().(client.Object)
+	if !change(obj, finalizer) {
+		return nil
+	}
+	return c.Patch(ctx, obj, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+}
+
+// importID returns the ID of the remote object obj should be bound to instead of creating one, if any.
+func importID(obj client.Object) string {
+	return strings.TrimSpace(obj.GetAnnotations()[coralogixv1alpha1.ImportIDAnnotation])
+}
+
+// pendingImportID returns the ID in the import-id annotation of obj, unless obj was bound to it already.
+// Resources of the kind are listed into list, and an ID another one imports as well is refused with an ImportConflictError.
+func pendingImportID(ctx context.Context, c client.Reader, obj syncStatusObject, list client.ObjectList) (string, error) {
+	id := importID(obj)
+	if id == "" || id == obj.GetSyncStatus().ImportedID {
+		return "", nil
+	}
+
+	if err := c.List(ctx, list); err != nil {
+		return "", fmt.Errorf("error on listing resources importing %s: %w", id, err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return "", err
+	}
+	for _, item := range items {
+		other, ok := item.(client.Object)
+		if !ok || client.ObjectKeyFromObject(other) == client.ObjectKeyFromObject(obj) {
+			continue
+		}
+		if importID(other) == id {
+			return "", &coralogixv1alpha1.ImportConflictError{ID: id, Resource: client.ObjectKeyFromObject(other).String()}
+		}
+	}
+	return id, nil
+}
+
+// resolvedDrift returns the drift to keep once the remote object matches the spec again.
+// A reported drift was resolved out-of-band, so it is cleared; an enforced drift is kept as the last one reverted.
+func resolvedDrift(lastDrift *coralogixv1alpha1.Drift) *coralogixv1alpha1.Drift {
+	if lastDrift != nil && !lastDrift.Enforced {
+		return nil
+	}
+	return lastDrift
+}
+
+// updateSyncStatus records the outcome of reconciling obj, reconcileErr, in its conditions.
+// The status is written to the latest version of the resource, since the reconciliation may have updated it.
+// In dry-run mode, the plan recorded on obj by recordPlan is written as well.
+func updateSyncStatus(ctx context.Context, c client.Client, obj syncStatusObject, dryRun bool, reconcileErr error) error {
+	generation := obj.GetGeneration()
+
+	latest := obj.DeepCopyObject().(syncStatusObject)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	syncStatus := latest.GetSyncStatus()
+	original := syncStatus.DeepCopy()
+	syncStatus.Plan = nil
+	if dryRun {
+		syncStatus.Plan = obj.GetSyncStatus().Plan
+	}
+	setSyncConditions(syncStatus, generation, reconcileErr)
+	if equality.Semantic.DeepEqual(original, syncStatus) {
+		return nil
+	}
+
+	if err := c.Status().Update(ctx, latest); err != nil {
+		return fmt.Errorf("error on updating sync status: %w", err)
+	}
+	return nil
+}
+
+func setSyncConditions(syncStatus *coralogixv1alpha1.SyncStatus, generation int64, reconcileErr error) {
+	syncStatus.ObservedGeneration = generation
+
+	if meta.IsStatusConditionTrue(syncStatus.Conditions, coralogixv1alpha1.ConditionTypePaused) {
+		meta.SetStatusCondition(&syncStatus.Conditions, metav1.Condition{
+			Type:               coralogixv1alpha1.ConditionTypePaused,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             coralogixv1alpha1.ReasonResumed,
+			Message:            "Reconciliation was resumed",
+		})
+	}
+
+	if reconcileErr != nil {
+		reason, message := errorReason(reconcileErr), truncateMessage(reconcileErr.Error())
+		meta.SetStatusCondition(&syncStatus.Conditions, metav1.Condition{
+			Type:               coralogixv1alpha1.ConditionTypeReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+		meta.SetStatusCondition(&syncStatus.Conditions, metav1.Condition{
+			Type:               coralogixv1alpha1.ConditionTypeSynced,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+		return
+	}
+
+	if plan := syncStatus.Plan; plan != nil {
+		setPlannedConditions(syncStatus, generation, plan)
+		return
+	}
+
+	now := metav1.Now()
+	syncStatus.LastSyncTime = &now
+	meta.SetStatusCondition(&syncStatus.Conditions, metav1.Condition{
+		Type:               coralogixv1alpha1.ConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             coralogixv1alpha1.ReasonSynced,
+		Message:            "The remote object exists",
+	})
+
+	if drift := syncStatus.LastDrift; drift != nil && !drift.Enforced {
+		meta.SetStatusCondition(&syncStatus.Conditions, metav1.Condition{
+			Type:               coralogixv1alpha1.ConditionTypeSynced,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             coralogixv1alpha1.ReasonDriftReported,
+			Message:            truncateMessage(fmt.Sprintf("The remote object differs from the spec at %s, and the drift policy is Report", drift.Field)),
+		})
+		return
+	}
+	meta.SetStatusCondition(&syncStatus.Conditions, metav1.Condition{
+		Type:               coralogixv1alpha1.ConditionTypeSynced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             coralogixv1alpha1.ReasonSynced,
+		Message:            "The remote object matches the spec",
+	})
+}
+
+// setPlannedConditions reports that the remote object was not synced with the spec, since the operator runs in dry-run mode.
+func setPlannedConditions(syncStatus *coralogixv1alpha1.SyncStatus, generation int64, plan *coralogixv1alpha1.Plan) {
+	ready := metav1.Condition{
+		Type:               coralogixv1alpha1.ConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             coralogixv1alpha1.ReasonSynced,
+		Message:            "The remote object exists",
+	}
+	if plan.Action == coralogixv1alpha1.PlanActionCreate {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = coralogixv1alpha1.ReasonPlanned
+		ready.Message = "Dry run: the remote object would be created"
+	}
+	meta.SetStatusCondition(&syncStatus.Conditions, ready)
+
+	message := fmt.Sprintf("Dry run: the remote object would be %s", planActionPastTense(plan.Action))
+	if plan.Field != "" {
+		message += fmt.Sprintf(", it differs from the spec at %s", plan.Field)
+	}
+	meta.SetStatusCondition(&syncStatus.Conditions, metav1.Condition{
+		Type:               coralogixv1alpha1.ConditionTypeSynced,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             coralogixv1alpha1.ReasonPlanned,
+		Message:            truncateMessage(message),
+	})
+}
+
+// errorReason returns the gRPC code of err as a condition reason, e.g. PermissionDenied, or WebhookNotReady when an
+// alert notifies an outbound webhook that isn't ready.
+func errorReason(err error) string {
+	var notReadyWebhook *coralogixv1alpha1.OutboundWebhookNotReadyError
+	var importConflict *coralogixv1alpha1.ImportConflictError
+	if errors.As(err, &notReadyWebhook) {
+		return coralogixv1alpha1.ReasonWebhookNotReady
+	}
+	if errors.As(err, &importConflict) {
+		return coralogixv1alpha1.ReasonImportConflict
+	}
+	if s, ok := status.FromError(err); ok && s.Code() != codes.OK && s.Code() != codes.Unknown {
+		return s.Code().String()
+	}
+	return coralogixv1alpha1.ReasonReconcileError
+}
+
+func truncateMessage(message string) string {
+	if len(message) > maxConditionMessageLength {
+		return message[:maxConditionMessageLength-3] + "..."
+	}
+	return message
+}
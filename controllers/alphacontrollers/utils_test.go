@@ -0,0 +1,234 @@
+package alphacontrollers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+func TestSetSyncConditions(t *testing.T) {
+	tests := []struct {
+		name         string
+		syncStatus   coralogixv1alpha1.SyncStatus
+		err          error
+		ready        metav1.ConditionStatus
+		synced       metav1.ConditionStatus
+		reason       string
+		lastSyncTime bool
+	}{
+		{
+			name:         "synced",
+			ready:        metav1.ConditionTrue,
+			synced:       metav1.ConditionTrue,
+			reason:       coralogixv1alpha1.ReasonSynced,
+			lastSyncTime: true,
+		},
+		{
+			name: "enforced drift",
+			syncStatus: coralogixv1alpha1.SyncStatus{
+				LastDrift: &coralogixv1alpha1.Drift{Field: "Severity", Enforced: true},
+			},
+			ready:        metav1.ConditionTrue,
+			synced:       metav1.ConditionTrue,
+			reason:       coralogixv1alpha1.ReasonSynced,
+			lastSyncTime: true,
+		},
+		{
+			name: "reported drift",
+			syncStatus: coralogixv1alpha1.SyncStatus{
+				LastDrift: &coralogixv1alpha1.Drift{Field: "Severity"},
+			},
+			ready:        metav1.ConditionTrue,
+			synced:       metav1.ConditionFalse,
+			reason:       coralogixv1alpha1.ReasonDriftReported,
+			lastSyncTime: true,
+		},
+		{
+			name: "planned creation",
+			syncStatus: coralogixv1alpha1.SyncStatus{
+				Plan: &coralogixv1alpha1.Plan{Action: coralogixv1alpha1.PlanActionCreate},
+			},
+			ready:  metav1.ConditionFalse,
+			synced: metav1.ConditionFalse,
+			reason: coralogixv1alpha1.ReasonPlanned,
+		},
+		{
+			name: "planned update",
+			syncStatus: coralogixv1alpha1.SyncStatus{
+				Plan: &coralogixv1alpha1.Plan{Action: coralogixv1alpha1.PlanActionUpdate, Field: "Severity"},
+			},
+			ready:  metav1.ConditionTrue,
+			synced: metav1.ConditionFalse,
+			reason: coralogixv1alpha1.ReasonPlanned,
+		},
+		{
+			name:   "gRPC error",
+			err:    fmt.Errorf("error on updating alert: %w", status.Error(codes.PermissionDenied, "api-key is not permitted")),
+			ready:  metav1.ConditionFalse,
+			synced: metav1.ConditionFalse,
+			reason: "PermissionDenied",
+		},
+		{
+			name:   "import conflict",
+			err:    &coralogixv1alpha1.ImportConflictError{ID: "alert-id", Resource: "monitoring/other"},
+			ready:  metav1.ConditionFalse,
+			synced: metav1.ConditionFalse,
+			reason: coralogixv1alpha1.ReasonImportConflict,
+		},
+		{
+			name:   "other error",
+			err:    fmt.Errorf("accountRef team-a is set, but accounts are not enabled"),
+			ready:  metav1.ConditionFalse,
+			synced: metav1.ConditionFalse,
+			reason: coralogixv1alpha1.ReasonReconcileError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setSyncConditions(&tt.syncStatus, 3, tt.err)
+
+			assert.Equal(t, int64(3), tt.syncStatus.ObservedGeneration)
+			assert.Equal(t, tt.lastSyncTime, tt.syncStatus.LastSyncTime != nil)
+
+			ready := meta.FindStatusCondition(tt.syncStatus.Conditions, coralogixv1alpha1.ConditionTypeReady)
+			synced := meta.FindStatusCondition(tt.syncStatus.Conditions, coralogixv1alpha1.ConditionTypeSynced)
+			if assert.NotNil(t, ready) && assert.NotNil(t, synced) {
+				assert.Equal(t, tt.ready, ready.Status)
+				assert.Equal(t, tt.synced, synced.Status)
+				assert.Equal(t, tt.reason, synced.Reason)
+				assert.Equal(t, int64(3), synced.ObservedGeneration)
+				if tt.err != nil {
+					assert.Equal(t, tt.err.Error(), synced.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestReleaseWithoutAccount(t *testing.T) {
+	accountNotFound := fmt.Errorf("failed to resolve: %w", &coralogixv1alpha1.AccountNotFoundError{
+		Kind: coralogixv1alpha1.CoralogixAccountKind, Name: "staging", Err: fmt.Errorf("not found"),
+	})
+	tests := []struct {
+		name     string
+		deleting bool
+		err      error
+		released bool
+	}{
+		{name: "deleted account of a deleted resource", deleting: true, err: accountNotFound, released: true},
+		{name: "deleted account of a live resource", err: accountNotFound},
+		{name: "other error of a deleted resource", deleting: true, err: fmt.Errorf("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+
+			alert := &coralogixv1alpha1.Alert{ObjectMeta: metav1.ObjectMeta{
+				Name: "alert", Namespace: "monitoring", Finalizers: []string{alertFinalizerName, "other"},
+			}}
+			if tt.deleting {
+				alert.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(alert).Build()
+			recorder := record.NewFakeRecorder(10)
+
+			released, err := releaseWithoutAccount(context.Background(), c, recorder, alert, alertFinalizerName, "alert", tt.err)
+			assert.Equal(t, tt.released, released)
+
+			latest := &coralogixv1alpha1.Alert{}
+			assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(alert), latest))
+			if tt.released {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"other"}, latest.Finalizers)
+				assert.Len(t, recorder.Events, 1)
+			} else {
+				assert.Equal(t, tt.err, err)
+				assert.Equal(t, []string{alertFinalizerName, "other"}, latest.Finalizers)
+				assert.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
+
+func TestPatchFinalizers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+
+	alert := &coralogixv1alpha1.Alert{
+		ObjectMeta: metav1.ObjectMeta{Name: "alert", Namespace: "monitoring"},
+		Spec:       coralogixv1alpha1.AlertSpec{Name: "alert"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(alert).Build()
+
+	// The in-memory spec isn't persisted along with the finalizers.
+	alert.Spec.Description = "defaulted"
+	assert.NoError(t, patchFinalizers(context.Background(), c, alert, controllerutil.AddFinalizer, alertFinalizerName))
+
+	latest := &coralogixv1alpha1.Alert{}
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(alert), latest))
+	assert.Equal(t, []string{alertFinalizerName}, latest.Finalizers)
+	assert.Empty(t, latest.Spec.Description)
+
+	assert.NoError(t, patchFinalizers(context.Background(), c, alert, controllerutil.RemoveFinalizer, alertFinalizerName))
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(alert), latest))
+	assert.Empty(t, latest.Finalizers)
+}
+
+func TestPendingImportID(t *testing.T) {
+	annotated := func(name, id string) *coralogixv1alpha1.Alert {
+		return &coralogixv1alpha1.Alert{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "monitoring", Annotations: map[string]string{coralogixv1alpha1.ImportIDAnnotation: id},
+		}}
+	}
+	imported := annotated("imported", "alert-id")
+	imported.Status.ImportedID = "alert-id"
+
+	tests := []struct {
+		name     string
+		alert    *coralogixv1alpha1.Alert
+		others   []client.Object
+		id       string
+		conflict bool
+	}{
+		{name: "no annotation", alert: &coralogixv1alpha1.Alert{ObjectMeta: metav1.ObjectMeta{Name: "alert", Namespace: "monitoring"}}},
+		{name: "not imported yet", alert: annotated("alert", "alert-id"), others: []client.Object{annotated("other", "other-id")}, id: "alert-id"},
+		{name: "imported already", alert: imported},
+		{name: "imported by another alert", alert: annotated("alert", "alert-id"), others: []client.Object{annotated("other", "alert-id")}, conflict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tt.others, tt.alert)...).Build()
+
+			id, err := pendingImportID(context.Background(), c, tt.alert, &coralogixv1alpha1.AlertList{})
+			assert.Equal(t, tt.id, id)
+			if tt.conflict {
+				var importConflict *coralogixv1alpha1.ImportConflictError
+				if assert.ErrorAs(t, err, &importConflict) {
+					assert.Equal(t, "monitoring/other", importConflict.Resource)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
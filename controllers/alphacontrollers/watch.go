@@ -0,0 +1,106 @@
+package alphacontrollers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// WatchFilter restricts the resources the operator reconciles to some namespaces and labels, so several operators,
+// e.g. one per team or Coralogix account, can share a cluster without reconciling the same resources.
+type WatchFilter struct {
+	// Namespaces are the namespaces of the reconciled resources. Empty means all namespaces.
+	Namespaces []string
+	// LabelSelector selects the reconciled resources. Nil means all resources.
+	LabelSelector labels.Selector
+}
+
+// ParseWatchFilter parses a comma separated list of namespaces and a label selector, e.g. "team-a,team-b" and
+// "team=a". Empty values don't restrict the reconciled resources.
+func ParseWatchFilter(namespaces, labelSelector string) (WatchFilter, error) {
+	var filter WatchFilter
+	for _, namespace := range strings.Split(namespaces, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" || slices.Contains(filter.Namespaces, namespace) {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return WatchFilter{}, fmt.Errorf("invalid watch namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+		filter.Namespaces = append(filter.Namespaces, namespace)
+	}
+
+	if strings.TrimSpace(labelSelector) != "" {
+		selector, err := labels.Parse(labelSelector)
+		if err != nil {
+			return WatchFilter{}, fmt.Errorf("invalid resource label selector %q: %w", labelSelector, err)
+		}
+		filter.LabelSelector = selector
+	}
+
+	return filter, nil
+}
+
+// Matches reports whether obj is in one of the watched namespaces and selected by the label selector.
+func (f WatchFilter) Matches(obj client.Object) bool {
+	if !f.watchesNamespace(obj.GetNamespace()) {
+		return false
+	}
+	return f.LabelSelector == nil || f.LabelSelector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// Predicate passes the events of the resources matching the filter.
+func (f WatchFilter) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(f.Matches)
+}
+
+// NamespacePredicate passes the events of the watched namespaces.
+func (f WatchFilter) NamespacePredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(namespace client.Object) bool {
+		return f.watchesNamespace(namespace.GetName())
+	})
+}
+
+// SelectedLabels returns the labels of a parent resource that the label selector refers to, so the resources
+// generated from it are selected as well.
+func (f WatchFilter) SelectedLabels(parentLabels map[string]string) map[string]string {
+	selected := make(map[string]string)
+	if f.LabelSelector == nil {
+		return selected
+	}
+
+	requirements, _ := f.LabelSelector.Requirements()
+	for _, requirement := range requirements {
+		if value, ok := parentLabels[requirement.Key()]; ok {
+			selected[requirement.Key()] = value
+		}
+	}
+	return selected
+}
+
+// CacheOptions restricts the manager cache to the watched namespaces, and the given objects to the label selector.
+func (f WatchFilter) CacheOptions(objects []client.Object) cache.Options {
+	var options cache.Options
+	if len(f.Namespaces) > 0 {
+		options.Namespaces = append([]string(nil), f.Namespaces...)
+	}
+
+	if f.LabelSelector != nil {
+		options.ByObject = make(map[client.Object]cache.ByObject, len(objects))
+		for _, obj := range objects {
+			options.ByObject[obj] = cache.ByObject{Label: f.LabelSelector}
+		}
+	}
+
+	return options
+}
+
+func (f WatchFilter) watchesNamespace(namespace string) bool {
+	return len(f.Namespaces) == 0 || slices.Contains(f.Namespaces, namespace)
+}
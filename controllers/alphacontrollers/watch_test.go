@@ -0,0 +1,93 @@
+package alphacontrollers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+func TestParseWatchFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		namespaces     string
+		labelSelector  string
+		wantNamespaces []string
+		shouldFail     bool
+	}{
+		{
+			name: "empty values watch everything",
+		},
+		{
+			name:           "namespaces are trimmed and deduplicated",
+			namespaces:     "team-a, team-b,team-a,",
+			labelSelector:  "team in (a,b)",
+			wantNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name:       "invalid namespace",
+			namespaces: "Team_A",
+			shouldFail: true,
+		},
+		{
+			name:          "invalid label selector",
+			labelSelector: "team in a",
+			shouldFail:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseWatchFilter(tt.namespaces, tt.labelSelector)
+			if tt.shouldFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNamespaces, filter.Namespaces)
+			assert.Equal(t, tt.labelSelector != "", filter.LabelSelector != nil)
+		})
+	}
+}
+
+func TestWatchFilterMatches(t *testing.T) {
+	filter, err := ParseWatchFilter("team-a", "team=a")
+	assert.NoError(t, err)
+
+	alert := func(namespace string, labels map[string]string) client.Object {
+		return &coralogixv1alpha1.Alert{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "alert", Labels: labels}}
+	}
+	assert.True(t, filter.Matches(alert("team-a", map[string]string{"team": "a", "app": "web"})))
+	assert.False(t, filter.Matches(alert("team-b", map[string]string{"team": "a"})))
+	assert.False(t, filter.Matches(alert("team-a", map[string]string{"team": "b"})))
+	assert.False(t, filter.Matches(alert("team-a", nil)))
+
+	assert.True(t, WatchFilter{}.Matches(alert("team-b", nil)))
+}
+
+func TestWatchFilterSelectedLabels(t *testing.T) {
+	filter, err := ParseWatchFilter("", "team=a,!legacy,tier in (1,2)")
+	assert.NoError(t, err)
+
+	selected := filter.SelectedLabels(map[string]string{"team": "a", "tier": "1", "app": "web"})
+	assert.Equal(t, map[string]string{"team": "a", "tier": "1"}, selected)
+
+	assert.Empty(t, WatchFilter{}.SelectedLabels(map[string]string{"team": "a"}))
+}
+
+func TestWatchFilterCacheOptions(t *testing.T) {
+	filter, err := ParseWatchFilter("team-a", "team=a")
+	assert.NoError(t, err)
+
+	alert := &coralogixv1alpha1.Alert{}
+	options := filter.CacheOptions([]client.Object{alert})
+	assert.Equal(t, []string{"team-a"}, options.Namespaces)
+	assert.Equal(t, filter.LabelSelector, options.ByObject[alert].Label)
+
+	options = WatchFilter{}.CacheOptions([]client.Object{alert})
+	assert.Empty(t, options.Namespaces)
+	assert.Empty(t, options.ByObject)
+}
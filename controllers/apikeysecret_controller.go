@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var apiKeyRotationsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "coralogix_operator_api_key_rotations_total",
+	Help: "Number of times the Coralogix API key was rotated from its Kubernetes Secret.",
+})
+
+func init() {
+	metrics.Registry.MustRegister(apiKeyRotationsTotal)
+}
+
+// APIKeySetter is implemented by clientsets whose API key can be replaced at runtime.
+type APIKeySetter interface {
+	SetAPIKey(apiKey string) bool
+}
+
+// SecretKeyReference points to a single key of a Kubernetes Secret.
+type SecretKeyReference struct {
+	types.NamespacedName
+	Key string
+}
+
+func (r SecretKeyReference) String() string {
+	return fmt.Sprintf("%s:%s", r.NamespacedName, r.Key)
+}
+
+// ParseSecretKeyReference parses a reference of the form <namespace>/<name>:<key>.
+func ParseSecretKeyReference(ref string) (SecretKeyReference, error) {
+	namespacedName, key, found := strings.Cut(ref, ":")
+	if !found || key == "" {
+		return SecretKeyReference{}, fmt.Errorf("secret reference %q should be of the form <namespace>/<name>:<key>", ref)
+	}
+
+	namespace, name, found := strings.Cut(namespacedName, "/")
+	if !found || namespace == "" || name == "" {
+		return SecretKeyReference{}, fmt.Errorf("secret reference %q should be of the form <namespace>/<name>:<key>", ref)
+	}
+
+	return SecretKeyReference{
+		NamespacedName: types.NamespacedName{Namespace: namespace, Name: name},
+		Key:            key,
+	}, nil
+}
+
+// GetAPIKeyFromSecret reads the API key referenced by ref.
+func GetAPIKeyFromSecret(ctx context.Context, reader client.Reader, ref SecretKeyReference) (string, error) {
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, ref.NamespacedName, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", ref.NamespacedName, err)
+	}
+	return apiKeyFromSecret(secret, ref.Key)
+}
+
+func apiKeyFromSecret(secret *corev1.Secret, key string) (string, error) {
+	apiKey := strings.TrimSpace(string(secret.Data[key]))
+	if apiKey == "" {
+		return "", fmt.Errorf("key %s not found or empty in secret %s/%s", key, secret.Namespace, secret.Name)
+	}
+	return apiKey, nil
+}
+
+// APIKeySecretReconciler keeps the API key used by the Coralogix clients in sync with a Kubernetes Secret.
+// The Secret is watched through a cache of its own, holding only that Secret, so the operator neither caches the other
+// Secrets of the cluster nor needs access to them.
+type APIKeySecretReconciler struct {
+	CoralogixClientSet APIKeySetter
+	Recorder           record.EventRecorder
+	SecretRef          SecretKeyReference
+
+	secrets cache.Cache
+}
+
+// The access to the api-key Secret is granted by a Role in its namespace, see the Helm chart.
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *APIKeySecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithValues("secret", req.NamespacedName.Name, "namespace", req.NamespacedName.Namespace)
+
+	secret := &corev1.Secret{}
+	if err := r.secrets.Get(ctx, r.SecretRef.NamespacedName, secret); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("API key secret not found, keeping the current API key")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+
+	apiKey, err := apiKeyFromSecret(secret, r.SecretRef.Key)
+	if err != nil {
+		log.Error(err, "Invalid API key secret, keeping the current API key")
+		r.Recorder.Event(secret, corev1.EventTypeWarning, "InvalidAPIKey", err.Error())
+		// The secret is watched, so it will be reconciled again once it is fixed.
+		return ctrl.Result{}, nil
+	}
+
+	if r.CoralogixClientSet.SetAPIKey(apiKey) {
+		log.Info("Coralogix API key was rotated")
+		apiKeyRotationsTotal.Inc()
+		r.Recorder.Event(secret, corev1.EventTypeNormal, "APIKeyRotated", "Coralogix API key was rotated")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *APIKeySecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	secrets, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:     mgr.GetScheme(),
+		Mapper:     mgr.GetRESTMapper(),
+		Namespaces: []string{r.SecretRef.Namespace},
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}: {Field: fields.OneTermEqualSelector("metadata.name", r.SecretRef.Name)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the cache of the api-key secret: %w", err)
+	}
+	if err = mgr.Add(secrets); err != nil {
+		return fmt.Errorf("failed to add the cache of the api-key secret: %w", err)
+	}
+	r.secrets = secrets
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("apikeysecret").
+		WatchesRawSource(source.Kind(secrets, &corev1.Secret{}), &handler.EnqueueRequestForObject{}).
+		Complete(r)
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseSecretKeyReference(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      string
+		expected SecretKeyReference
+		wantErr  bool
+	}{
+		{
+			name: "valid reference",
+			ref:  "coralogix/operator-secret:apiKey",
+			expected: SecretKeyReference{
+				NamespacedName: types.NamespacedName{Namespace: "coralogix", Name: "operator-secret"},
+				Key:            "apiKey",
+			},
+		},
+		{name: "missing key", ref: "coralogix/operator-secret", wantErr: true},
+		{name: "empty key", ref: "coralogix/operator-secret:", wantErr: true},
+		{name: "missing namespace", ref: "operator-secret:apiKey", wantErr: true},
+		{name: "empty name", ref: "coralogix/:apiKey", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := ParseSecretKeyReference(tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
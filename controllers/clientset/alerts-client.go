@@ -10,8 +10,10 @@ import (
 type AlertsClientInterface interface {
 	CreateAlert(ctx context.Context, req *alerts.CreateAlertRequest) (*alerts.CreateAlertResponse, error)
 	GetAlert(ctx context.Context, req *alerts.GetAlertByUniqueIdRequest) (*alerts.GetAlertByUniqueIdResponse, error)
+	GetAlerts(ctx context.Context, req *alerts.GetAlertsRequest) (*alerts.GetAlertsResponse, error)
 	UpdateAlert(ctx context.Context, req *alerts.UpdateAlertByUniqueIdRequest) (*alerts.UpdateAlertByUniqueIdResponse, error)
 	DeleteAlert(ctx context.Context, req *alerts.DeleteAlertByUniqueIdRequest) (*alerts.DeleteAlertByUniqueIdResponse, error)
+	ValidateAlert(ctx context.Context, req *alerts.ValidateAlertRequest) (*alerts.ValidateAlertResponse, error)
 }
 
 type AlertsClient struct {
@@ -24,9 +26,7 @@ func (a AlertsClient) CreateAlert(ctx context.Context, req *alerts.CreateAlertRe
 		return nil, err
 	}
 
-	conn := callProperties.Connection
-	defer conn.Close()
-	client := alerts.NewAlertServiceClient(conn)
+	client := alerts.NewAlertServiceClient(callProperties.Connection)
 
 	return client.CreateAlert(callProperties.Ctx, req, callProperties.CallOptions...)
 }
@@ -37,22 +37,29 @@ func (a AlertsClient) GetAlert(ctx context.Context, req *alerts.GetAlertByUnique
 		return nil, err
 	}
 
-	conn := callProperties.Connection
-	defer conn.Close()
-	client := alerts.NewAlertServiceClient(conn)
+	client := alerts.NewAlertServiceClient(callProperties.Connection)
 
 	return client.GetAlertByUniqueId(callProperties.Ctx, req, callProperties.CallOptions...)
 }
 
+func (a AlertsClient) GetAlerts(ctx context.Context, req *alerts.GetAlertsRequest) (*alerts.GetAlertsResponse, error) {
+	callProperties, err := a.callPropertiesCreator.GetCallProperties(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client := alerts.NewAlertServiceClient(callProperties.Connection)
+
+	return client.GetAlerts(callProperties.Ctx, req, callProperties.CallOptions...)
+}
+
 func (a AlertsClient) UpdateAlert(ctx context.Context, req *alerts.UpdateAlertByUniqueIdRequest) (*alerts.UpdateAlertByUniqueIdResponse, error) {
 	callProperties, err := a.callPropertiesCreator.GetCallProperties(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	conn := callProperties.Connection
-	defer conn.Close()
-	client := alerts.NewAlertServiceClient(conn)
+	client := alerts.NewAlertServiceClient(callProperties.Connection)
 
 	return client.UpdateAlertByUniqueId(callProperties.Ctx, req, callProperties.CallOptions...)
 }
@@ -63,13 +70,22 @@ func (a AlertsClient) DeleteAlert(ctx context.Context, req *alerts.DeleteAlertBy
 		return nil, err
 	}
 
-	conn := callProperties.Connection
-	defer conn.Close()
-	client := alerts.NewAlertServiceClient(conn)
+	client := alerts.NewAlertServiceClient(callProperties.Connection)
 
 	return client.DeleteAlertByUniqueId(callProperties.Ctx, req, callProperties.CallOptions...)
 }
 
+func (a AlertsClient) ValidateAlert(ctx context.Context, req *alerts.ValidateAlertRequest) (*alerts.ValidateAlertResponse, error) {
+	callProperties, err := a.callPropertiesCreator.GetCallProperties(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client := alerts.NewAlertServiceClient(callProperties.Connection)
+
+	return client.ValidateAlert(callProperties.Ctx, req, callProperties.CallOptions...)
+}
+
 func NewAlertsClient(c *CallPropertiesCreator) *AlertsClient {
 	return &AlertsClient{callPropertiesCreator: c}
 }
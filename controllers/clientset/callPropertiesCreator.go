@@ -4,18 +4,41 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"sync"
 	"time"
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/health"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 )
 
+const (
+	keepaliveTime         = 30 * time.Second
+	keepaliveTimeout      = 10 * time.Second
+	reconnectBaseDelay    = time.Second
+	reconnectMaxDelay     = 30 * time.Second
+	reconnectMinTimeout   = 10 * time.Second
+	healthCheckedLBConfig = `{"loadBalancingConfig":[{"round_robin":{}}],"healthCheckConfig":{"serviceName":""}}`
+)
+
+// CallPropertiesCreator holds a single long-lived connection to the Coralogix API that is shared by all the
+// typed clients built on top of it. The connection is established lazily on the first call and kept open until Close.
 type CallPropertiesCreator struct {
 	targetUrl string
-	apiKey    string
 	//allowRetry bool
+
+	apiKeyMu sync.RWMutex
+	apiKey   string
+
+	rateLimiter *RateLimiter
+
+	connOnce sync.Once
+	conn     *grpc.ClientConn
+	connErr  error
 }
 
 type CallProperties struct {
@@ -24,10 +47,14 @@ type CallProperties struct {
 	CallOptions []grpc.CallOption
 }
 
-func (c CallPropertiesCreator) GetCallProperties(ctx context.Context) (*CallProperties, error) {
+// GetCallProperties returns the shared connection along with an authenticated context.
+// The returned connection is owned by the CallPropertiesCreator and must not be closed by the caller.
+func (c *CallPropertiesCreator) GetCallProperties(ctx context.Context) (*CallProperties, error) {
+	c.apiKeyMu.RLock()
 	ctx = createAuthContext(ctx, c.apiKey)
+	c.apiKeyMu.RUnlock()
 
-	conn, err := createSecureConnection(c.targetUrl)
+	conn, err := c.connection()
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +64,37 @@ func (c CallPropertiesCreator) GetCallProperties(ctx context.Context) (*CallProp
 	return &CallProperties{Ctx: ctx, Connection: conn, CallOptions: callOptions}, nil
 }
 
+// SetAPIKey replaces the API key used by all subsequent calls. The connection itself is kept, since the key
+// is sent per call. It reports whether the key differs from the one in use.
+func (c *CallPropertiesCreator) SetAPIKey(apiKey string) bool {
+	c.apiKeyMu.Lock()
+	defer c.apiKeyMu.Unlock()
+	if c.apiKey == apiKey {
+		return false
+	}
+	c.apiKey = apiKey
+	return true
+}
+
+// Close releases the shared connection. It is safe to call Close even if no call has been made.
+func (c *CallPropertiesCreator) Close() error {
+	// Make sure a concurrent first call can't create a connection after it was closed.
+	c.connOnce.Do(func() {
+		c.connErr = fmt.Errorf("connection to %s is closed", c.targetUrl)
+	})
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+func (c *CallPropertiesCreator) connection() (*grpc.ClientConn, error) {
+	c.connOnce.Do(func() {
+		c.conn, c.connErr = createSecureConnection(c.targetUrl, c.rateLimiter)
+	})
+	return c.conn, c.connErr
+}
+
 func createCallOptions() []grpc.CallOption {
 	var callOptions []grpc.CallOption
 	callOptions = append(callOptions, grpc_retry.WithMax(5))
@@ -44,9 +102,30 @@ func createCallOptions() []grpc.CallOption {
 	return callOptions
 }
 
-func createSecureConnection(targetUrl string) (*grpc.ClientConn, error) {
-	return grpc.Dial(targetUrl,
-		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+func createSecureConnection(targetUrl string, rateLimiter *RateLimiter) (*grpc.ClientConn, error) {
+	var dialOptions []grpc.DialOption
+	if rateLimiter != nil {
+		dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(rateLimiter.UnaryClientInterceptor()))
+	}
+
+	return grpc.NewClient(targetUrl, append(dialOptions,
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                keepaliveTime,
+			Timeout:             keepaliveTimeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  reconnectBaseDelay,
+				Multiplier: backoff.DefaultConfig.Multiplier,
+				Jitter:     backoff.DefaultConfig.Jitter,
+				MaxDelay:   reconnectMaxDelay,
+			},
+			MinConnectTimeout: reconnectMinTimeout,
+		}),
+		grpc.WithDefaultServiceConfig(healthCheckedLBConfig),
+	)...)
 }
 
 func createAuthContext(ctx context.Context, apiKey string) context.Context {
@@ -55,9 +134,23 @@ func createAuthContext(ctx context.Context, apiKey string) context.Context {
 	return ctx
 }
 
-func NewCallPropertiesCreator(targetUrl, apiKey string) *CallPropertiesCreator {
-	return &CallPropertiesCreator{
+// Option configures the connection of a CallPropertiesCreator.
+type Option func(*CallPropertiesCreator)
+
+// WithRateLimiter throttles the calls made on the connection with rateLimiter.
+func WithRateLimiter(rateLimiter *RateLimiter) Option {
+	return func(c *CallPropertiesCreator) {
+		c.rateLimiter = rateLimiter
+	}
+}
+
+func NewCallPropertiesCreator(targetUrl, apiKey string, opts ...Option) *CallPropertiesCreator {
+	c := &CallPropertiesCreator{
 		targetUrl: targetUrl,
 		apiKey:    apiKey,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
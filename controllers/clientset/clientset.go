@@ -1,6 +1,6 @@
 package clientset
 
-import cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+import "context"
 
 //go:generate mockgen -destination=../mock_clientset/mock_clientset.go -package=mock_clientset github.com/coralogix/coralogix-operator/controllers/clientset ClientSetInterface
 type ClientSetInterface interface {
@@ -10,11 +10,13 @@ type ClientSetInterface interface {
 	OutboundWebhooks() OutboundWebhooksClientInterface
 }
 
+// ClientSet groups the typed Coralogix clients. All of them share a single gRPC connection, which is released by Close.
 type ClientSet struct {
-	ruleGroups          *cxsdk.RuleGroupsClient
-	alerts              *AlertsClient
-	recordingRuleGroups *cxsdk.RecordingRuleGroupSetsClient
-	outboundWebhooks    *cxsdk.WebhooksClient
+	callPropertiesCreator *CallPropertiesCreator
+	ruleGroups            *RuleGroupsClient
+	alerts                *AlertsClient
+	recordingRuleGroups   *RecordingRulesGroupsClient
+	outboundWebhooks      *OutboundWebhooksClient
 }
 
 func (c *ClientSet) RuleGroups() RuleGroupsClientInterface {
@@ -33,14 +35,36 @@ func (c *ClientSet) OutboundWebhooks() OutboundWebhooksClientInterface {
 	return c.outboundWebhooks
 }
 
-func NewClientSet(targetUrl, apiKey string) ClientSetInterface {
-	apikeyCPC := NewCallPropertiesCreator(targetUrl, apiKey)
-	SDKAPIKeyCPC := cxsdk.NewCallPropertiesCreator(targetUrl, cxsdk.NewAuthContext(apiKey, apiKey))
+// SetAPIKey rotates the API key used by all the clients of the ClientSet and reports whether it changed.
+func (c *ClientSet) SetAPIKey(apiKey string) bool {
+	return c.callPropertiesCreator.SetAPIKey(apiKey)
+}
+
+// Close closes the connection shared by the clients of the ClientSet.
+func (c *ClientSet) Close() error {
+	return c.callPropertiesCreator.Close()
+}
+
+// Start implements manager.Runnable, so the shared connection is closed when the manager stops.
+func (c *ClientSet) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return c.Close()
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The connection is used by all replicas,
+// so it has to be closed regardless of leadership.
+func (c *ClientSet) NeedLeaderElection() bool {
+	return false
+}
+
+func NewClientSet(targetUrl, apiKey string, opts ...Option) *ClientSet {
+	apikeyCPC := NewCallPropertiesCreator(targetUrl, apiKey, opts...)
 
 	return &ClientSet{
-		ruleGroups:          cxsdk.NewRuleGroupsClient(SDKAPIKeyCPC),
-		alerts:              NewAlertsClient(apikeyCPC),
-		recordingRuleGroups: cxsdk.NewRecordingRuleGroupSetsClient(SDKAPIKeyCPC),
-		outboundWebhooks:    cxsdk.NewWebhooksClient(SDKAPIKeyCPC),
+		callPropertiesCreator: apikeyCPC,
+		ruleGroups:            NewRuleGroupsClient(apikeyCPC),
+		alerts:                NewAlertsClient(apikeyCPC),
+		recordingRuleGroups:   NewRecordingRulesGroupsClient(apikeyCPC),
+		outboundWebhooks:      NewOutboundWebhooksClient(apikeyCPC),
 	}
 }
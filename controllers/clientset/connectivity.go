@@ -0,0 +1,109 @@
+package clientset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const connectivityCheckTimeout = 10 * time.Second
+
+var errConnectivityNotChecked = errors.New("connectivity to the Coralogix API has not been checked yet")
+
+// ConnectivityChecker periodically makes a cheap authenticated call to the Coralogix API, listing the outbound webhooks,
+// and reports the result of each one. It doesn't take part in the readiness probe, as the pod also serves the webhooks
+// of the custom resources, which would all fail with an unready pod whenever the Coralogix API does.
+type ConnectivityChecker struct {
+	client    OutboundWebhooksClientInterface
+	targetUrl string
+	interval  time.Duration
+	report    func(error)
+
+	mu  sync.RWMutex
+	err error
+}
+
+// NewConnectivityChecker returns a checker of the connectivity of client to targetUrl, made every interval once started.
+// The result of each check is passed to report, e.g. to set a metric, unless it is nil.
+func NewConnectivityChecker(client OutboundWebhooksClientInterface, targetUrl string, interval time.Duration, report func(error)) *ConnectivityChecker {
+	return &ConnectivityChecker{
+		client:    client,
+		targetUrl: targetUrl,
+		interval:  interval,
+		report:    report,
+		err:       errConnectivityNotChecked,
+	}
+}
+
+// Check makes the call and returns why it failed, if it did. The result is returned by Err until the next check.
+func (c *ConnectivityChecker) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, connectivityCheckTimeout)
+	defer cancel()
+
+	_, err := c.client.List(ctx, &cxsdk.ListAllOutgoingWebhooksRequest{})
+	err = c.describe(err)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil && (c.err == nil || c.err.Error() != err.Error()) {
+		log.FromContext(ctx).Error(err, "Coralogix API connectivity check failed")
+	} else if err == nil && c.err != nil && c.err != errConnectivityNotChecked {
+		log.FromContext(ctx).Info("Coralogix API connectivity restored")
+	}
+	c.err = err
+	if c.report != nil {
+		c.report(err)
+	}
+	return err
+}
+
+// Err returns the failure of the last check.
+func (c *ConnectivityChecker) Err() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.err
+}
+
+// Start implements manager.Runnable, checking the connectivity every interval until ctx is done.
+func (c *ConnectivityChecker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			_ = c.Check(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica reports its own connectivity.
+func (c *ConnectivityChecker) NeedLeaderElection() bool {
+	return false
+}
+
+func (c *ConnectivityChecker) describe(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		return fmt.Errorf("the api-key was rejected by the Coralogix API at %s, check that it is valid and matches the region or domain: %w", c.targetUrl, err)
+	case codes.PermissionDenied:
+		// The api-key was authenticated, it just can't manage outbound webhooks, which may not be used.
+		return nil
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return fmt.Errorf("the Coralogix API at %s is unreachable: %w", c.targetUrl, err)
+	default:
+		return fmt.Errorf("error on calling the Coralogix API at %s: %w", c.targetUrl, err)
+	}
+}
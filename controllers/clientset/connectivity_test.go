@@ -0,0 +1,76 @@
+package clientset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type listWebhooksClient struct {
+	OutboundWebhooksClientInterface
+	err error
+}
+
+func (c *listWebhooksClient) List(context.Context, *cxsdk.ListAllOutgoingWebhooksRequest) (*cxsdk.ListAllOutgoingWebhooksResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &cxsdk.ListAllOutgoingWebhooksResponse{}, nil
+}
+
+func TestConnectivityChecker(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantError string
+	}{
+		{
+			name: "reachable with a valid api-key",
+		},
+		{
+			name:      "invalid api-key",
+			err:       status.Error(codes.Unauthenticated, "invalid token"),
+			wantError: "the api-key was rejected",
+		},
+		{
+			name: "api-key without outbound webhooks permission",
+			err:  status.Error(codes.PermissionDenied, "forbidden"),
+		},
+		{
+			name:      "unreachable endpoint",
+			err:       status.Error(codes.Unavailable, "connection refused"),
+			wantError: "is unreachable",
+		},
+		{
+			name:      "other error",
+			err:       errors.New("boom"),
+			wantError: "error on calling the Coralogix API",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []error
+			checker := NewConnectivityChecker(&listWebhooksClient{err: tt.err}, "ng-api-grpc.eu2.coralogix.com:443", 0, func(err error) {
+				reported = append(reported, err)
+			})
+			assert.ErrorIs(t, checker.Err(), errConnectivityNotChecked)
+
+			err := checker.Check(context.Background())
+			assert.Equal(t, []error{err}, reported)
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+				assert.NoError(t, checker.Err())
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantError)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, err, checker.Err())
+		})
+	}
+}
@@ -2,6 +2,10 @@ package clientset
 
 import (
 	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 
 	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
 )
@@ -13,4 +17,99 @@ type OutboundWebhooksClientInterface interface {
 	Update(ctx context.Context, req *cxsdk.UpdateOutgoingWebhookRequest) (*cxsdk.UpdateOutgoingWebhookResponse, error)
 	Delete(ctx context.Context, req *cxsdk.DeleteOutgoingWebhookRequest) (*cxsdk.DeleteOutgoingWebhookResponse, error)
 	List(ctx context.Context, req *cxsdk.ListAllOutgoingWebhooksRequest) (*cxsdk.ListAllOutgoingWebhooksResponse, error)
+	TestByID(ctx context.Context, req *cxsdk.TestExistingOutgoingWebhookRequest) (TestOutgoingWebhookResponse, error)
+}
+
+// TestOutgoingWebhookResponse is the outcome of sending a test notification through an outbound webhook.
+// The SDK doesn't export the type of the response, only the types of its outcomes.
+type TestOutgoingWebhookResponse interface {
+	GetSuccess() *cxsdk.TestOutgoingWebhookSuccess
+	GetFailure() *cxsdk.TestOutgoingWebhookFailure
+}
+
+const (
+	getOutgoingWebhookRPC      = "/com.coralogix.outgoing_webhooks.v1.OutgoingWebhooksService/GetOutgoingWebhook"
+	createOutgoingWebhookRPC   = "/com.coralogix.outgoing_webhooks.v1.OutgoingWebhooksService/CreateOutgoingWebhook"
+	updateOutgoingWebhookRPC   = "/com.coralogix.outgoing_webhooks.v1.OutgoingWebhooksService/UpdateOutgoingWebhook"
+	deleteOutgoingWebhookRPC   = "/com.coralogix.outgoing_webhooks.v1.OutgoingWebhooksService/DeleteOutgoingWebhook"
+	listAllOutgoingWebhooksRPC = "/com.coralogix.outgoing_webhooks.v1.OutgoingWebhooksService/ListAllOutgoingWebhooks"
+	testOutgoingWebhookRPC     = "/com.coralogix.outgoing_webhooks.v1.OutgoingWebhooksService/TestExistingOutgoingWebhook"
+)
+
+type OutboundWebhooksClient struct {
+	callPropertiesCreator *CallPropertiesCreator
+}
+
+func (o OutboundWebhooksClient) Create(ctx context.Context, req *cxsdk.CreateOutgoingWebhookRequest) (*cxsdk.CreateOutgoingWebhookResponse, error) {
+	resp := &cxsdk.CreateOutgoingWebhookResponse{}
+	if err := o.invoke(ctx, createOutgoingWebhookRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (o OutboundWebhooksClient) Get(ctx context.Context, req *cxsdk.GetOutgoingWebhookRequest) (*cxsdk.GetOutgoingWebhookResponse, error) {
+	resp := &cxsdk.GetOutgoingWebhookResponse{}
+	if err := o.invoke(ctx, getOutgoingWebhookRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (o OutboundWebhooksClient) Update(ctx context.Context, req *cxsdk.UpdateOutgoingWebhookRequest) (*cxsdk.UpdateOutgoingWebhookResponse, error) {
+	resp := &cxsdk.UpdateOutgoingWebhookResponse{}
+	if err := o.invoke(ctx, updateOutgoingWebhookRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (o OutboundWebhooksClient) Delete(ctx context.Context, req *cxsdk.DeleteOutgoingWebhookRequest) (*cxsdk.DeleteOutgoingWebhookResponse, error) {
+	resp := &cxsdk.DeleteOutgoingWebhookResponse{}
+	if err := o.invoke(ctx, deleteOutgoingWebhookRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (o OutboundWebhooksClient) List(ctx context.Context, req *cxsdk.ListAllOutgoingWebhooksRequest) (*cxsdk.ListAllOutgoingWebhooksResponse, error) {
+	resp := &cxsdk.ListAllOutgoingWebhooksResponse{}
+	if err := o.invoke(ctx, listAllOutgoingWebhooksRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (o OutboundWebhooksClient) TestByID(ctx context.Context, req *cxsdk.TestExistingOutgoingWebhookRequest) (TestOutgoingWebhookResponse, error) {
+	resp, err := newTestOutgoingWebhookResponse()
+	if err != nil {
+		return nil, err
+	}
+	if err := o.invoke(ctx, testOutgoingWebhookRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp.(TestOutgoingWebhookResponse), nil
+}
+
+// newTestOutgoingWebhookResponse returns an empty response of TestByID, found by the name of the message holding its outcomes.
+func newTestOutgoingWebhookResponse() (protoreflect.ProtoMessage, error) {
+	name := (&cxsdk.TestOutgoingWebhookSuccess{}).ProtoReflect().Descriptor().Parent().FullName()
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("error on finding the message type %s: %w", name, err)
+	}
+	return messageType.New().Interface(), nil
+}
+
+func (o OutboundWebhooksClient) invoke(ctx context.Context, method string, req, resp any) error {
+	callProperties, err := o.callPropertiesCreator.GetCallProperties(ctx)
+	if err != nil {
+		return err
+	}
+
+	return callProperties.Connection.Invoke(callProperties.Ctx, method, req, resp, callProperties.CallOptions...)
+}
+
+func NewOutboundWebhooksClient(c *CallPropertiesCreator) *OutboundWebhooksClient {
+	return &OutboundWebhooksClient{callPropertiesCreator: c}
 }
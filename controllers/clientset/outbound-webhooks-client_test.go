@@ -0,0 +1,15 @@
+package clientset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTestOutgoingWebhookResponse(t *testing.T) {
+	resp, err := newTestOutgoingWebhookResponse()
+	if assert.NoError(t, err) {
+		assert.Implements(t, (*TestOutgoingWebhookResponse)(nil), resp)
+		assert.Equal(t, "com.coralogix.outgoing_webhooks.v1.TestOutgoingWebhookResponse", string(resp.ProtoReflect().Descriptor().FullName()))
+	}
+}
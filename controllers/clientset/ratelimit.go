@@ -0,0 +1,213 @@
+package clientset
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/coralogix/coralogix-operator/controllers/overrides"
+)
+
+const (
+	retryAfterHeader        = "retry-after"
+	defaultMaxThrottleRetry = 3
+	throttleBaseDelay       = time.Second
+	throttleMaxDelay        = 30 * time.Second
+)
+
+// RateLimitServices are the Coralogix API services that can be limited separately.
+var RateLimitServices = []string{"alerts", "rule-groups", "recording-rule-groups", "outbound-webhooks"}
+
+// serviceMethodPrefixes maps the gRPC methods to the services they belong to, by their prefix.
+var serviceMethodPrefixes = map[string]string{
+	"alerts":                "/com.coralogix.alerts.v2.AlertService/",
+	"rule-groups":           "/com.coralogix.rules.v1.RuleGroupsService/",
+	"recording-rule-groups": "/rule_manager.groups.RuleGroupSets/",
+	"outbound-webhooks":     "/com.coralogix.outgoing_webhooks.v1.OutgoingWebhooksService/",
+}
+
+// RateLimit is a token bucket refilled with QPS tokens per second and holding up to Burst tokens.
+// A zero QPS means no limit.
+type RateLimit struct {
+	QPS   float64
+	Burst int
+}
+
+// RateLimits holds the limit shared by all the calls to the Coralogix API, and the limits of specific services.
+type RateLimits struct {
+	Default   RateLimit
+	ByService map[string]RateLimit
+}
+
+// ParseRateLimits parses a comma separated list of a default limit and <service>=<limit> overrides,
+// e.g. "20:40,alerts=5". A limit is <qps>[:<burst>], and its burst defaults to the QPS rounded up.
+func ParseRateLimits(value string) (RateLimits, error) {
+	defaultLimit, byService, err := overrides.Parse(value, "rate limit", "service", RateLimitServices, parseRateLimit)
+	if err != nil {
+		return RateLimits{}, err
+	}
+	return RateLimits{Default: defaultLimit, ByService: byService}, nil
+}
+
+func parseRateLimit(value string) (RateLimit, error) {
+	qpsValue, burstValue, hasBurst := strings.Cut(value, ":")
+	qps, err := strconv.ParseFloat(qpsValue, 64)
+	if err != nil {
+		return RateLimit{}, err
+	}
+	if qps < 0 {
+		return RateLimit{}, fmt.Errorf("qps must not be negative")
+	}
+
+	burst := int(math.Ceil(qps))
+	if hasBurst {
+		if burst, err = strconv.Atoi(burstValue); err != nil {
+			return RateLimit{}, err
+		}
+		if burst < 1 {
+			return RateLimit{}, fmt.Errorf("burst must be positive")
+		}
+	}
+
+	return RateLimit{QPS: qps, Burst: burst}, nil
+}
+
+// RateLimiter throttles the calls to the Coralogix API. It is shared by all the clientsets, so the limits
+// apply to the operator as a whole. When Coralogix answers with ResourceExhausted, the calls to the same
+// service are held back for the delay it asks for, or an exponential backoff, and the call is retried.
+type RateLimiter struct {
+	global     *rate.Limiter
+	services   map[string]*rate.Limiter
+	maxRetries int
+
+	mu          sync.Mutex
+	pausedUntil map[string]time.Time
+}
+
+// NewRateLimiter returns a RateLimiter enforcing limits.
+func NewRateLimiter(limits RateLimits) *RateLimiter {
+	l := &RateLimiter{
+		global:      newLimiter(limits.Default),
+		services:    make(map[string]*rate.Limiter),
+		maxRetries:  defaultMaxThrottleRetry,
+		pausedUntil: make(map[string]time.Time),
+	}
+	for service, limit := range limits.ByService {
+		l.services[service] = newLimiter(limit)
+	}
+	return l
+}
+
+func newLimiter(limit RateLimit) *rate.Limiter {
+	if limit.QPS == 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(limit.QPS), limit.Burst)
+}
+
+// Wait blocks until a call to service is allowed, or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, service string) error {
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil[service])
+	l.mu.Unlock()
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if l.global != nil {
+		if err := l.global.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if limiter := l.services[service]; limiter != nil {
+		return limiter.Wait(ctx)
+	}
+	return nil
+}
+
+// pause holds back the calls to service for delay, unless they are already held back for longer.
+func (l *RateLimiter) pause(service string, delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(delay); until.After(l.pausedUntil[service]) {
+		l.pausedUntil[service] = until
+	}
+}
+
+// UnaryClientInterceptor returns an interceptor applying the limits to the calls made on a connection.
+func (l *RateLimiter) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service := rateLimitService(method)
+		for attempt := 0; ; attempt++ {
+			if err := l.Wait(ctx, service); err != nil {
+				return err
+			}
+
+			var header, trailer metadata.MD
+			err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header), grpc.Trailer(&trailer))...)
+			if status.Code(err) != codes.ResourceExhausted || attempt >= l.maxRetries {
+				return err
+			}
+			l.pause(service, throttleDelay(err, attempt, header, trailer))
+		}
+	}
+}
+
+func rateLimitService(method string) string {
+	for service, prefix := range serviceMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return service
+		}
+	}
+	return method
+}
+
+// throttleDelay returns how long to wait after a ResourceExhausted error, preferring the delay asked by Coralogix
+// in a RetryInfo detail or a retry-after header over an exponential backoff.
+func throttleDelay(err error, attempt int, mds ...metadata.MD) time.Duration {
+	for _, detail := range status.Convert(err).Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.GetRetryDelay() != nil {
+			return min(retryInfo.GetRetryDelay().AsDuration(), throttleMaxDelay)
+		}
+	}
+
+	for _, md := range mds {
+		for _, value := range md.Get(retryAfterHeader) {
+			if delay, ok := parseRetryAfter(value); ok {
+				return min(delay, throttleMaxDelay)
+			}
+		}
+	}
+
+	return min(throttleBaseDelay<<attempt, throttleMaxDelay)
+}
+
+// parseRetryAfter parses a retry-after value, either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,114 @@
+package clientset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		want       RateLimits
+		shouldFail bool
+	}{
+		{
+			name:  "empty value is unlimited",
+			value: "",
+			want:  RateLimits{ByService: map[string]RateLimit{}},
+		},
+		{
+			name:  "burst defaults to the qps rounded up",
+			value: "2.5",
+			want:  RateLimits{Default: RateLimit{QPS: 2.5, Burst: 3}, ByService: map[string]RateLimit{}},
+		},
+		{
+			name:  "overrides per service",
+			value: "20:40, alerts=5,outbound-webhooks=1:2",
+			want: RateLimits{
+				Default: RateLimit{QPS: 20, Burst: 40},
+				ByService: map[string]RateLimit{
+					"alerts":            {QPS: 5, Burst: 5},
+					"outbound-webhooks": {QPS: 1, Burst: 2},
+				},
+			},
+		},
+		{
+			name:       "unknown service",
+			value:      "dashboards=5",
+			shouldFail: true,
+		},
+		{
+			name:       "invalid qps",
+			value:      "fast",
+			shouldFail: true,
+		},
+		{
+			name:       "zero burst",
+			value:      "5:0",
+			shouldFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := ParseRateLimits(tt.value)
+			if tt.shouldFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, limits)
+		})
+	}
+}
+
+func TestThrottleDelay(t *testing.T) {
+	exhausted := status.New(codes.ResourceExhausted, "slow down")
+	withRetryInfo, err := exhausted.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(2 * time.Second)})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2*time.Second, throttleDelay(withRetryInfo.Err(), 0))
+	assert.Equal(t, 5*time.Second, throttleDelay(exhausted.Err(), 0, metadata.Pairs(retryAfterHeader, "5")))
+	assert.Equal(t, throttleMaxDelay, throttleDelay(exhausted.Err(), 0, metadata.Pairs(retryAfterHeader, "3600")))
+	assert.Equal(t, 4*time.Second, throttleDelay(exhausted.Err(), 2))
+}
+
+func TestRateLimiterRetriesResourceExhausted(t *testing.T) {
+	limiter := NewRateLimiter(RateLimits{})
+	exhausted, err := status.New(codes.ResourceExhausted, "slow down").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(10 * time.Millisecond)})
+	assert.NoError(t, err)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls < 3 {
+			return exhausted.Err()
+		}
+		return nil
+	}
+
+	interceptor := limiter.UnaryClientInterceptor()
+	err = interceptor(context.Background(), "/com.coralogix.alerts.v2.AlertService/GetAlertByUniqueId", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	invoker = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return exhausted.Err()
+	}
+	err = interceptor(context.Background(), "/com.coralogix.alerts.v2.AlertService/GetAlertByUniqueId", nil, nil, nil, invoker)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, defaultMaxThrottleRetry+1, calls)
+}
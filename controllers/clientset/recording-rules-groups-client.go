@@ -14,4 +14,61 @@ type RecordingRulesGroupsClientInterface interface {
 	Get(ctx context.Context, req *cxsdk.GetRuleGroupSetRequest) (*cxsdk.GetRuleGroupSetResponse, error)
 	Update(ctx context.Context, req *cxsdk.UpdateRuleGroupSetRequest) (*emptypb.Empty, error)
 	Delete(ctx context.Context, req *cxsdk.DeleteRuleGroupSetRequest) (*emptypb.Empty, error)
-}
\ No newline at end of file
+	List(ctx context.Context) (*cxsdk.ListRuleGroupSetResponse, error)
+}
+type RecordingRulesGroupsClient struct {
+	callPropertiesCreator *CallPropertiesCreator
+}
+
+func (r RecordingRulesGroupsClient) Create(ctx context.Context, req *cxsdk.CreateRuleGroupSetRequest) (*cxsdk.CreateRuleGroupSetResponse, error) {
+	resp := &cxsdk.CreateRuleGroupSetResponse{}
+	if err := r.invoke(ctx, cxsdk.CreateRuleGroupSetRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r RecordingRulesGroupsClient) Get(ctx context.Context, req *cxsdk.GetRuleGroupSetRequest) (*cxsdk.GetRuleGroupSetResponse, error) {
+	resp := &cxsdk.GetRuleGroupSetResponse{}
+	if err := r.invoke(ctx, cxsdk.GetRuleGroupSetRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r RecordingRulesGroupsClient) Update(ctx context.Context, req *cxsdk.UpdateRuleGroupSetRequest) (*emptypb.Empty, error) {
+	resp := &emptypb.Empty{}
+	if err := r.invoke(ctx, cxsdk.UpdateRuleGroupSetRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r RecordingRulesGroupsClient) Delete(ctx context.Context, req *cxsdk.DeleteRuleGroupSetRequest) (*emptypb.Empty, error) {
+	resp := &emptypb.Empty{}
+	if err := r.invoke(ctx, cxsdk.DeleteRuleGroupSetRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r RecordingRulesGroupsClient) List(ctx context.Context) (*cxsdk.ListRuleGroupSetResponse, error) {
+	resp := &cxsdk.ListRuleGroupSetResponse{}
+	if err := r.invoke(ctx, cxsdk.ListRuleGroupSetRPC, &emptypb.Empty{}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r RecordingRulesGroupsClient) invoke(ctx context.Context, method string, req, resp any) error {
+	callProperties, err := r.callPropertiesCreator.GetCallProperties(ctx)
+	if err != nil {
+		return err
+	}
+
+	return callProperties.Connection.Invoke(callProperties.Ctx, method, req, resp, callProperties.CallOptions...)
+}
+
+func NewRecordingRulesGroupsClient(c *CallPropertiesCreator) *RecordingRulesGroupsClient {
+	return &RecordingRulesGroupsClient{callPropertiesCreator: c}
+}
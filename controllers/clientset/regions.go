@@ -0,0 +1,47 @@
+package clientset
+
+import (
+	"fmt"
+
+	utils "github.com/coralogix/coralogix-operator/apis"
+)
+
+var (
+	RegionToGrpcUrl = map[string]string{
+		"APAC1":   "ng-api-grpc.app.coralogix.in:443",
+		"AP1":     "ng-api-grpc.app.coralogix.in:443",
+		"APAC2":   "ng-api-grpc.coralogixsg.com:443",
+		"AP2":     "ng-api-grpc.coralogixsg.com:443",
+		"EUROPE1": "ng-api-grpc.coralogix.com:443",
+		"EU1":     "ng-api-grpc.coralogix.com:443",
+		"EUROPE2": "ng-api-grpc.eu2.coralogix.com:443",
+		"EU2":     "ng-api-grpc.eu2.coralogix.com:443",
+		"USA1":    "ng-api-grpc.coralogix.us:443",
+		"US1":     "ng-api-grpc.coralogix.us:443",
+		"USA2":    "ng-api-grpc.cx498.coralogix.com:443",
+		"US2":     "ng-api-grpc.cx498.coralogix.com:443",
+	}
+	ValidRegions = utils.GetKeys(RegionToGrpcUrl)
+)
+
+// TargetUrl returns the gRPC endpoint of either a Coralogix region or a private domain.
+// Exactly one of region and domain must be set.
+func TargetUrl(region, domain string) (string, error) {
+	if region != "" && domain != "" {
+		return "", fmt.Errorf("region and domain are mutually exclusive")
+	}
+
+	if region != "" {
+		targetUrl, ok := RegionToGrpcUrl[region]
+		if !ok {
+			return "", fmt.Errorf("region value is '%s', but can be one of %q", region, ValidRegions)
+		}
+		return targetUrl, nil
+	}
+
+	if domain != "" {
+		return fmt.Sprintf("ng-api-grpc.%s:443", domain), nil
+	}
+
+	return "", fmt.Errorf("region or domain must be set")
+}
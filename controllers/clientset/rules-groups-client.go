@@ -2,6 +2,9 @@ package clientset
 
 import (
 	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoregistry"
 
 	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
 )
@@ -12,4 +15,97 @@ type RuleGroupsClientInterface interface {
 	Get(ctx context.Context, req *cxsdk.GetRuleGroupRequest) (*cxsdk.GetRuleGroupResponse, error)
 	Update(ctx context.Context, req *cxsdk.UpdateRuleGroupRequest) (*cxsdk.UpdateRuleGroupResponse, error)
 	Delete(ctx context.Context, req *cxsdk.DeleteRuleGroupRequest) (*cxsdk.DeleteRuleGroupResponse, error)
+	List(ctx context.Context) ([]*cxsdk.RuleGroup, error)
+}
+
+const (
+	getRuleGroupRPC    = "/com.coralogix.rules.v1.RuleGroupsService/GetRuleGroup"
+	createRuleGroupRPC = "/com.coralogix.rules.v1.RuleGroupsService/CreateRuleGroup"
+	updateRuleGroupRPC = "/com.coralogix.rules.v1.RuleGroupsService/UpdateRuleGroup"
+	deleteRuleGroupRPC = "/com.coralogix.rules.v1.RuleGroupsService/DeleteRuleGroup"
+	listRuleGroupsRPC  = "/com.coralogix.rules.v1.RuleGroupsService/ListRuleGroups"
+)
+
+// The SDK doesn't export the messages of ListRuleGroups, so they are created from the descriptors it registers.
+const (
+	listRuleGroupsRequestName  = "com.coralogix.rules.v1.ListRuleGroupsRequest"
+	listRuleGroupsResponseName = "com.coralogix.rules.v1.ListRuleGroupsResponse"
+	ruleGroupsFieldName        = "rule_groups"
+)
+
+type RuleGroupsClient struct {
+	callPropertiesCreator *CallPropertiesCreator
+}
+
+func (r RuleGroupsClient) Create(ctx context.Context, req *cxsdk.CreateRuleGroupRequest) (*cxsdk.CreateRuleGroupResponse, error) {
+	resp := &cxsdk.CreateRuleGroupResponse{}
+	if err := r.invoke(ctx, createRuleGroupRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r RuleGroupsClient) Get(ctx context.Context, req *cxsdk.GetRuleGroupRequest) (*cxsdk.GetRuleGroupResponse, error) {
+	resp := &cxsdk.GetRuleGroupResponse{}
+	if err := r.invoke(ctx, getRuleGroupRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r RuleGroupsClient) Update(ctx context.Context, req *cxsdk.UpdateRuleGroupRequest) (*cxsdk.UpdateRuleGroupResponse, error) {
+	resp := &cxsdk.UpdateRuleGroupResponse{}
+	if err := r.invoke(ctx, updateRuleGroupRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r RuleGroupsClient) Delete(ctx context.Context, req *cxsdk.DeleteRuleGroupRequest) (*cxsdk.DeleteRuleGroupResponse, error) {
+	resp := &cxsdk.DeleteRuleGroupResponse{}
+	if err := r.invoke(ctx, deleteRuleGroupRPC, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// List returns all the rule groups of the team.
+func (r RuleGroupsClient) List(ctx context.Context) ([]*cxsdk.RuleGroup, error) {
+	reqType, err := protoregistry.GlobalTypes.FindMessageByName(listRuleGroupsRequestName)
+	if err != nil {
+		return nil, err
+	}
+	respType, err := protoregistry.GlobalTypes.FindMessageByName(listRuleGroupsResponseName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := respType.New()
+	if err = r.invoke(ctx, listRuleGroupsRPC, reqType.New().Interface(), resp.Interface()); err != nil {
+		return nil, err
+	}
+
+	list := resp.Get(respType.Descriptor().Fields().ByName(ruleGroupsFieldName)).List()
+	ruleGroups := make([]*cxsdk.RuleGroup, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		ruleGroup, ok := list.Get(i).Message().Interface().(*cxsdk.RuleGroup)
+		if !ok {
+			return nil, fmt.Errorf("unexpected rule group type %T", list.Get(i).Message().Interface())
+		}
+		ruleGroups = append(ruleGroups, ruleGroup)
+	}
+	return ruleGroups, nil
+}
+
+func (r RuleGroupsClient) invoke(ctx context.Context, method string, req, resp any) error {
+	callProperties, err := r.callPropertiesCreator.GetCallProperties(ctx)
+	if err != nil {
+		return err
+	}
+
+	return callProperties.Connection.Invoke(callProperties.Ctx, method, req, resp, callProperties.CallOptions...)
+}
+
+func NewRuleGroupsClient(c *CallPropertiesCreator) *RuleGroupsClient {
+	return &RuleGroupsClient{callPropertiesCreator: c}
 }
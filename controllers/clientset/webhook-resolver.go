@@ -0,0 +1,117 @@
+package clientset
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// WebhookResolver looks up the outbound webhooks of a Coralogix account by name and by external ID, for the alerts
+// notifying them. The webhooks are listed at most once per ttl, unless one isn't found, since it may have been created
+// since they were listed.
+type WebhookResolver struct {
+	client OutboundWebhooksClientInterface
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	listedAt  time.Time
+	idsByName map[string]uint32
+	namesByID map[uint32]string
+}
+
+// NewWebhookResolver returns a resolver of the outbound webhooks listed with client, cached for ttl.
+// A zero ttl lists them on every lookup.
+func NewWebhookResolver(client OutboundWebhooksClientInterface, ttl time.Duration) *WebhookResolver {
+	return &WebhookResolver{client: client, ttl: ttl, now: time.Now}
+}
+
+// WebhookID returns the external ID of the outbound webhook named name, and false if there is none.
+func (r *WebhookResolver) WebhookID(ctx context.Context, name string) (uint32, bool, error) {
+	var id uint32
+	found, err := r.lookup(ctx, func() (ok bool) {
+		id, ok = r.idsByName[name]
+		return ok
+	})
+	return id, found, err
+}
+
+// WebhookName returns the name of the outbound webhook with the external ID id, and false if there is none.
+func (r *WebhookResolver) WebhookName(ctx context.Context, id uint32) (string, bool, error) {
+	var name string
+	found, err := r.lookup(ctx, func() (ok bool) {
+		name, ok = r.namesByID[id]
+		return ok
+	})
+	return name, found, err
+}
+
+// lookup runs find on the cached webhooks, listing them again when they expired or find fails on them.
+func (r *WebhookResolver) lookup(ctx context.Context, find func() bool) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.listedAt.IsZero() && r.now().Sub(r.listedAt) < r.ttl && find() {
+		return true, nil
+	}
+	if err := r.list(ctx); err != nil {
+		return false, err
+	}
+	return find(), nil
+}
+
+func (r *WebhookResolver) list(ctx context.Context) error {
+	log.FromContext(ctx).V(1).Info("Listing all outgoing webhooks")
+	resp, err := r.client.List(ctx, &cxsdk.ListAllOutgoingWebhooksRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list all outgoing webhooks %w", err)
+	}
+
+	r.idsByName = make(map[string]uint32, len(resp.GetDeployed()))
+	r.namesByID = make(map[uint32]string, len(resp.GetDeployed()))
+	for _, webhook := range resp.GetDeployed() {
+		r.idsByName[webhook.GetName().GetValue()] = webhook.GetExternalId().GetValue()
+		r.namesByID[webhook.GetExternalId().GetValue()] = webhook.GetName().GetValue()
+	}
+	r.listedAt = r.now()
+	return nil
+}
+
+// WebhookResolvers keeps a WebhookResolver per outbound webhooks client, i.e. per Coralogix account, so the alerts of
+// an account share the webhooks listed for any of them.
+type WebhookResolvers struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	resolvers map[OutboundWebhooksClientInterface]*WebhookResolver
+}
+
+// NewWebhookResolvers returns resolvers caching the outbound webhooks of each account for ttl.
+func NewWebhookResolvers(ttl time.Duration) *WebhookResolvers {
+	return &WebhookResolvers{ttl: ttl, resolvers: make(map[OutboundWebhooksClientInterface]*WebhookResolver)}
+}
+
+// For returns the resolver of the outbound webhooks listed with client.
+func (r *WebhookResolvers) For(client OutboundWebhooksClientInterface) *WebhookResolver {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resolver, ok := r.resolvers[client]
+	if !ok {
+		resolver = NewWebhookResolver(client, r.ttl)
+		r.resolvers[client] = resolver
+	}
+	return resolver
+}
+
+// Forget drops the resolver of client, e.g. once the clientset of an account is closed, so its webhooks aren't kept.
+func (r *WebhookResolvers) Forget(client OutboundWebhooksClientInterface) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.resolvers, client)
+}
@@ -0,0 +1,83 @@
+package clientset
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type countingWebhooksClient struct {
+	OutboundWebhooksClientInterface
+	webhooks map[string]uint32
+	lists    int
+}
+
+func (c *countingWebhooksClient) List(context.Context, *cxsdk.ListAllOutgoingWebhooksRequest) (*cxsdk.ListAllOutgoingWebhooksResponse, error) {
+	c.lists++
+	// The summaries of the deployed webhooks aren't exported by the SDK, so the response is built from its JSON.
+	deployed := make([]map[string]any, 0, len(c.webhooks))
+	for name, id := range c.webhooks {
+		deployed = append(deployed, map[string]any{"name": name, "externalId": id})
+	}
+	body, err := json.Marshal(map[string]any{"deployed": deployed})
+	if err != nil {
+		return nil, err
+	}
+	resp := &cxsdk.ListAllOutgoingWebhooksResponse{}
+	return resp, protojson.Unmarshal(body, resp)
+}
+
+func TestWebhookResolver(t *testing.T) {
+	ctx := context.Background()
+	client := &countingWebhooksClient{webhooks: map[string]uint32{"slack": 1}}
+	now := time.Now()
+	resolver := NewWebhookResolver(client, time.Minute)
+	resolver.now = func() time.Time { return now }
+
+	id, found, err := resolver.WebhookID(ctx, "slack")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, uint32(1), id)
+	name, found, err := resolver.WebhookName(ctx, 1)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "slack", name)
+	assert.Equal(t, 1, client.lists, "the webhooks should be listed once within the ttl")
+
+	client.webhooks["pagerduty"] = 2
+	id, found, err = resolver.WebhookID(ctx, "pagerduty")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, uint32(2), id)
+	assert.Equal(t, 2, client.lists, "a missing webhook should list them again")
+
+	_, found, err = resolver.WebhookID(ctx, "missing")
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Equal(t, 3, client.lists)
+
+	client.webhooks["slack"] = 3
+	now = now.Add(time.Minute)
+	id, _, err = resolver.WebhookID(ctx, "slack")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), id, "the webhooks should be listed again once expired")
+	assert.Equal(t, 4, client.lists)
+}
+
+func TestWebhookResolvers(t *testing.T) {
+	resolvers := NewWebhookResolvers(time.Minute)
+	client, otherClient := &countingWebhooksClient{}, &countingWebhooksClient{}
+
+	assert.Same(t, resolvers.For(client), resolvers.For(client))
+	assert.NotSame(t, resolvers.For(client), resolvers.For(otherClient))
+
+	resolver, otherResolver := resolvers.For(client), resolvers.For(otherClient)
+	resolvers.Forget(client)
+	assert.NotSame(t, resolver, resolvers.For(client), "a forgotten client should get a new resolver")
+	assert.Same(t, otherResolver, resolvers.For(otherClient))
+}
@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
+	alerts "github.com/coralogix/coralogix-operator/controllers/clientset/grpc/alerts/v2"
+)
+
+// InstrumentClientSet returns a clientset that records the count, latency and gRPC code of every call made through clientSet.
+func InstrumentClientSet(clientSet clientset.ClientSetInterface) clientset.ClientSetInterface {
+	return &instrumentedClientSet{
+		ruleGroups:          instrumentedRuleGroups{client: clientSet.RuleGroups()},
+		alerts:              instrumentedAlerts{client: clientSet.Alerts()},
+		recordingRuleGroups: instrumentedRecordingRuleGroups{client: clientSet.RecordingRuleGroups()},
+		outboundWebhooks:    instrumentedOutboundWebhooks{client: clientSet.OutboundWebhooks()},
+	}
+}
+
+type instrumentedClientSet struct {
+	ruleGroups          instrumentedRuleGroups
+	alerts              instrumentedAlerts
+	recordingRuleGroups instrumentedRecordingRuleGroups
+	outboundWebhooks    instrumentedOutboundWebhooks
+}
+
+func (c *instrumentedClientSet) RuleGroups() clientset.RuleGroupsClientInterface {
+	return c.ruleGroups
+}
+
+func (c *instrumentedClientSet) Alerts() clientset.AlertsClientInterface {
+	return c.alerts
+}
+
+func (c *instrumentedClientSet) RecordingRuleGroups() clientset.RecordingRulesGroupsClientInterface {
+	return c.recordingRuleGroups
+}
+
+func (c *instrumentedClientSet) OutboundWebhooks() clientset.OutboundWebhooksClientInterface {
+	return c.outboundWebhooks
+}
+
+type instrumentedAlerts struct {
+	client clientset.AlertsClientInterface
+}
+
+func (c instrumentedAlerts) CreateAlert(ctx context.Context, req *alerts.CreateAlertRequest) (*alerts.CreateAlertResponse, error) {
+	start := time.Now()
+	resp, err := c.client.CreateAlert(ctx, req)
+	observeCall("alerts", "CreateAlert", start, err)
+	return resp, err
+}
+
+func (c instrumentedAlerts) GetAlert(ctx context.Context, req *alerts.GetAlertByUniqueIdRequest) (*alerts.GetAlertByUniqueIdResponse, error) {
+	start := time.Now()
+	resp, err := c.client.GetAlert(ctx, req)
+	observeCall("alerts", "GetAlert", start, err)
+	return resp, err
+}
+
+func (c instrumentedAlerts) GetAlerts(ctx context.Context, req *alerts.GetAlertsRequest) (*alerts.GetAlertsResponse, error) {
+	start := time.Now()
+	resp, err := c.client.GetAlerts(ctx, req)
+	observeCall("alerts", "GetAlerts", start, err)
+	return resp, err
+}
+
+func (c instrumentedAlerts) UpdateAlert(ctx context.Context, req *alerts.UpdateAlertByUniqueIdRequest) (*alerts.UpdateAlertByUniqueIdResponse, error) {
+	start := time.Now()
+	resp, err := c.client.UpdateAlert(ctx, req)
+	observeCall("alerts", "UpdateAlert", start, err)
+	return resp, err
+}
+
+func (c instrumentedAlerts) DeleteAlert(ctx context.Context, req *alerts.DeleteAlertByUniqueIdRequest) (*alerts.DeleteAlertByUniqueIdResponse, error) {
+	start := time.Now()
+	resp, err := c.client.DeleteAlert(ctx, req)
+	observeCall("alerts", "DeleteAlert", start, err)
+	return resp, err
+}
+
+func (c instrumentedAlerts) ValidateAlert(ctx context.Context, req *alerts.ValidateAlertRequest) (*alerts.ValidateAlertResponse, error) {
+	start := time.Now()
+	resp, err := c.client.ValidateAlert(ctx, req)
+	observeCall("alerts", "ValidateAlert", start, err)
+	return resp, err
+}
+
+type instrumentedRuleGroups struct {
+	client clientset.RuleGroupsClientInterface
+}
+
+func (c instrumentedRuleGroups) Create(ctx context.Context, req *cxsdk.CreateRuleGroupRequest) (*cxsdk.CreateRuleGroupResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Create(ctx, req)
+	observeCall("rule-groups", "Create", start, err)
+	return resp, err
+}
+
+func (c instrumentedRuleGroups) Get(ctx context.Context, req *cxsdk.GetRuleGroupRequest) (*cxsdk.GetRuleGroupResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Get(ctx, req)
+	observeCall("rule-groups", "Get", start, err)
+	return resp, err
+}
+
+func (c instrumentedRuleGroups) Update(ctx context.Context, req *cxsdk.UpdateRuleGroupRequest) (*cxsdk.UpdateRuleGroupResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Update(ctx, req)
+	observeCall("rule-groups", "Update", start, err)
+	return resp, err
+}
+
+func (c instrumentedRuleGroups) Delete(ctx context.Context, req *cxsdk.DeleteRuleGroupRequest) (*cxsdk.DeleteRuleGroupResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Delete(ctx, req)
+	observeCall("rule-groups", "Delete", start, err)
+	return resp, err
+}
+
+func (c instrumentedRuleGroups) List(ctx context.Context) ([]*cxsdk.RuleGroup, error) {
+	start := time.Now()
+	resp, err := c.client.List(ctx)
+	observeCall("rule-groups", "List", start, err)
+	return resp, err
+}
+
+type instrumentedRecordingRuleGroups struct {
+	client clientset.RecordingRulesGroupsClientInterface
+}
+
+func (c instrumentedRecordingRuleGroups) Create(ctx context.Context, req *cxsdk.CreateRuleGroupSetRequest) (*cxsdk.CreateRuleGroupSetResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Create(ctx, req)
+	observeCall("recording-rule-groups", "Create", start, err)
+	return resp, err
+}
+
+func (c instrumentedRecordingRuleGroups) Get(ctx context.Context, req *cxsdk.GetRuleGroupSetRequest) (*cxsdk.GetRuleGroupSetResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Get(ctx, req)
+	observeCall("recording-rule-groups", "Get", start, err)
+	return resp, err
+}
+
+func (c instrumentedRecordingRuleGroups) Update(ctx context.Context, req *cxsdk.UpdateRuleGroupSetRequest) (*emptypb.Empty, error) {
+	start := time.Now()
+	resp, err := c.client.Update(ctx, req)
+	observeCall("recording-rule-groups", "Update", start, err)
+	return resp, err
+}
+
+func (c instrumentedRecordingRuleGroups) Delete(ctx context.Context, req *cxsdk.DeleteRuleGroupSetRequest) (*emptypb.Empty, error) {
+	start := time.Now()
+	resp, err := c.client.Delete(ctx, req)
+	observeCall("recording-rule-groups", "Delete", start, err)
+	return resp, err
+}
+
+func (c instrumentedRecordingRuleGroups) List(ctx context.Context) (*cxsdk.ListRuleGroupSetResponse, error) {
+	start := time.Now()
+	resp, err := c.client.List(ctx)
+	observeCall("recording-rule-groups", "List", start, err)
+	return resp, err
+}
+
+type instrumentedOutboundWebhooks struct {
+	client clientset.OutboundWebhooksClientInterface
+}
+
+func (c instrumentedOutboundWebhooks) Create(ctx context.Context, req *cxsdk.CreateOutgoingWebhookRequest) (*cxsdk.CreateOutgoingWebhookResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Create(ctx, req)
+	observeCall("outbound-webhooks", "Create", start, err)
+	return resp, err
+}
+
+func (c instrumentedOutboundWebhooks) Get(ctx context.Context, req *cxsdk.GetOutgoingWebhookRequest) (*cxsdk.GetOutgoingWebhookResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Get(ctx, req)
+	observeCall("outbound-webhooks", "Get", start, err)
+	return resp, err
+}
+
+func (c instrumentedOutboundWebhooks) Update(ctx context.Context, req *cxsdk.UpdateOutgoingWebhookRequest) (*cxsdk.UpdateOutgoingWebhookResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Update(ctx, req)
+	observeCall("outbound-webhooks", "Update", start, err)
+	return resp, err
+}
+
+func (c instrumentedOutboundWebhooks) Delete(ctx context.Context, req *cxsdk.DeleteOutgoingWebhookRequest) (*cxsdk.DeleteOutgoingWebhookResponse, error) {
+	start := time.Now()
+	resp, err := c.client.Delete(ctx, req)
+	observeCall("outbound-webhooks", "Delete", start, err)
+	return resp, err
+}
+
+func (c instrumentedOutboundWebhooks) List(ctx context.Context, req *cxsdk.ListAllOutgoingWebhooksRequest) (*cxsdk.ListAllOutgoingWebhooksResponse, error) {
+	start := time.Now()
+	resp, err := c.client.List(ctx, req)
+	observeCall("outbound-webhooks", "List", start, err)
+	return resp, err
+}
+
+func (c instrumentedOutboundWebhooks) TestByID(ctx context.Context, req *cxsdk.TestExistingOutgoingWebhookRequest) (clientset.TestOutgoingWebhookResponse, error) {
+	start := time.Now()
+	resp, err := c.client.TestByID(ctx, req)
+	observeCall("outbound-webhooks", "TestByID", start, err)
+	return resp, err
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "coralogix_operator"
+
+var (
+	apiCallsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_calls_total",
+		Help:      "Number of calls to the Coralogix API, by service, method and gRPC code.",
+	}, []string{"service", "method", "code"})
+
+	apiCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "api_call_duration_seconds",
+		Help:      "Latency of the calls to the Coralogix API, by service, method and gRPC code.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"service", "method", "code"})
+
+	apiConnectivity = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "api_connectivity",
+		Help:      "Whether the last check of the api-key and the connectivity to the Coralogix API succeeded (1) or failed (0).",
+	})
+)
+
+func init() {
+	// The registry of controller-runtime is served on --metrics-bind-address, along with its own metrics.
+	ctrlmetrics.Registry.MustRegister(apiCallsTotal, apiCallDuration, apiConnectivity)
+}
+
+// observeCall records a call to the Coralogix API that started at start and returned err.
+func observeCall(service, method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	apiCallsTotal.WithLabelValues(service, method, code).Inc()
+	apiCallDuration.WithLabelValues(service, method, code).Observe(time.Since(start).Seconds())
+}
+
+// ReportAPIConnectivity records the result of a connectivity check of the Coralogix API, which failed with err if not nil.
+func ReportAPIConnectivity(err error) {
+	if err != nil {
+		apiConnectivity.Set(0)
+		return
+	}
+	apiConnectivity.Set(1)
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	alerts "github.com/coralogix/coralogix-operator/controllers/clientset/grpc/alerts/v2"
+	"github.com/coralogix/coralogix-operator/controllers/mock_clientset"
+)
+
+func TestInstrumentClientSet(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAlerts := mock_clientset.NewMockAlertsClientInterface(mockCtrl)
+	mockClientSet := mock_clientset.NewMockClientSetInterface(mockCtrl)
+	mockClientSet.EXPECT().Alerts().Return(mockAlerts).AnyTimes()
+	mockClientSet.EXPECT().RuleGroups().Return(nil).AnyTimes()
+	mockClientSet.EXPECT().RecordingRuleGroups().Return(nil).AnyTimes()
+	mockClientSet.EXPECT().OutboundWebhooks().Return(nil).AnyTimes()
+
+	mockAlerts.EXPECT().GetAlert(gomock.Any(), gomock.Any()).Return(&alerts.GetAlertByUniqueIdResponse{}, nil)
+	mockAlerts.EXPECT().GetAlert(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "not found"))
+
+	clientSet := InstrumentClientSet(mockClientSet)
+	okBefore := testutil.ToFloat64(apiCallsTotal.WithLabelValues("alerts", "GetAlert", "OK"))
+	notFoundBefore := testutil.ToFloat64(apiCallsTotal.WithLabelValues("alerts", "GetAlert", "NotFound"))
+
+	_, err := clientSet.Alerts().GetAlert(context.Background(), &alerts.GetAlertByUniqueIdRequest{})
+	assert.NoError(t, err)
+	_, err = clientSet.Alerts().GetAlert(context.Background(), &alerts.GetAlertByUniqueIdRequest{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	assert.Equal(t, okBefore+1, testutil.ToFloat64(apiCallsTotal.WithLabelValues("alerts", "GetAlert", "OK")))
+	assert.Equal(t, notFoundBefore+1, testutil.ToFloat64(apiCallsTotal.WithLabelValues("alerts", "GetAlert", "NotFound")))
+}
+
+func TestReportAPIConnectivity(t *testing.T) {
+	ReportAPIConnectivity(status.Error(codes.Unavailable, "connection refused"))
+	assert.Equal(t, float64(0), testutil.ToFloat64(apiConnectivity))
+
+	ReportAPIConnectivity(nil)
+	assert.Equal(t, float64(1), testutil.ToFloat64(apiConnectivity))
+}
+
+func TestResourcesCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
+
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		alertWithConditions("synced", metav1.ConditionTrue, metav1.ConditionTrue, coralogixv1alpha1.ReasonSynced),
+		alertWithConditions("drifted", metav1.ConditionTrue, metav1.ConditionFalse, coralogixv1alpha1.ReasonDriftReported),
+		alertWithConditions("failing", metav1.ConditionFalse, metav1.ConditionFalse, "PermissionDenied"),
+		&coralogixv1alpha1.Alert{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"}},
+	).Build()
+
+	collector := NewResourcesCollector(reader)
+	// One series per state for each of the four kinds.
+	assert.Equal(t, 16, testutil.CollectAndCount(collector))
+
+	expected := map[string]string{"synced": StateSynced, "drifted": StateDrifted, "failing": StateFailing, "pending": StatePending}
+	list := &coralogixv1alpha1.AlertList{}
+	assert.NoError(t, reader.List(context.Background(), list))
+	for _, alert := range list.Items {
+		assert.Equal(t, expected[alert.Name], State(alert.GetSyncStatus()), alert.Name)
+	}
+}
+
+func alertWithConditions(name string, ready, synced metav1.ConditionStatus, syncedReason string) *coralogixv1alpha1.Alert {
+	alert := &coralogixv1alpha1.Alert{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	meta.SetStatusCondition(&alert.Status.Conditions, metav1.Condition{Type: coralogixv1alpha1.ConditionTypeReady, Status: ready, Reason: syncedReason})
+	meta.SetStatusCondition(&alert.Status.Conditions, metav1.Condition{Type: coralogixv1alpha1.ConditionTypeSynced, Status: synced, Reason: syncedReason})
+	return alert
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+)
+
+// States of the managed resources, derived from their Ready and Synced conditions.
+const (
+	StateSynced  = "synced"
+	StateDrifted = "drifted"
+	StateFailing = "failing"
+	StatePending = "pending"
+)
+
+const listTimeout = 10 * time.Second
+
+var resourcesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "resources"),
+	"Number of resources managed by the operator, by kind and state.",
+	[]string{"kind", "state"}, nil,
+)
+
+// ResourcesCollector counts the managed resources of each kind and state when scraped,
+// so the gauges never hold resources that were deleted in the meantime.
+type ResourcesCollector struct {
+	client.Reader
+}
+
+// NewResourcesCollector returns a ResourcesCollector that lists the resources using reader,
+// usually the cached client of the manager.
+func NewResourcesCollector(reader client.Reader) *ResourcesCollector {
+	return &ResourcesCollector{Reader: reader}
+}
+
+// RegisterResourcesCollector registers a ResourcesCollector reading with reader in the registry of controller-runtime.
+func RegisterResourcesCollector(reader client.Reader) error {
+	return ctrlmetrics.Registry.Register(NewResourcesCollector(reader))
+}
+
+func (c *ResourcesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- resourcesDesc
+}
+
+func (c *ResourcesCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), listTimeout)
+	defer cancel()
+
+	for kind, list := range map[string]client.ObjectList{
+		"Alert":                 &coralogixv1alpha1.AlertList{},
+		"RuleGroup":             &coralogixv1alpha1.RuleGroupList{},
+		"RecordingRuleGroupSet": &coralogixv1alpha1.RecordingRuleGroupSetList{},
+		"OutboundWebhook":       &coralogixv1alpha1.OutboundWebhookList{},
+	} {
+		if err := c.List(ctx, list); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to list resources for metrics", "kind", kind)
+			continue
+		}
+
+		counts := map[string]float64{StateSynced: 0, StateDrifted: 0, StateFailing: 0, StatePending: 0}
+		if err := meta.EachListItem(list, func(obj runtime.Object) error {
+			if o, ok := obj.(syncStatusObject); ok {
+				counts[State(o.GetSyncStatus())]++
+			}
+			return nil
+		}); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to count resources for metrics", "kind", kind)
+			continue
+		}
+
+		for state, count := range counts {
+			ch <- prometheus.MustNewConstMetric(resourcesDesc, prometheus.GaugeValue, count, kind, state)
+		}
+	}
+}
+
+type syncStatusObject interface {
+	GetSyncStatus() *coralogixv1alpha1.SyncStatus
+}
+
+// State returns the state of a resource with syncStatus. Resources that were not reconciled yet are pending.
+func State(syncStatus *coralogixv1alpha1.SyncStatus) string {
+	ready := meta.FindStatusCondition(syncStatus.Conditions, coralogixv1alpha1.ConditionTypeReady)
+	synced := meta.FindStatusCondition(syncStatus.Conditions, coralogixv1alpha1.ConditionTypeSynced)
+	switch {
+	case ready == nil:
+		return StatePending
+	case ready.Status != metav1.ConditionTrue:
+		return StateFailing
+	case synced != nil && synced.Reason == coralogixv1alpha1.ReasonDriftReported:
+		return StateDrifted
+	default:
+		return StateSynced
+	}
+}
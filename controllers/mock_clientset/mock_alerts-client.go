@@ -84,6 +84,21 @@ func (mr *MockAlertsClientInterfaceMockRecorder) GetAlert(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlert", reflect.TypeOf((*MockAlertsClientInterface)(nil).GetAlert), arg0, arg1)
 }
 
+// GetAlerts mocks base method.
+func (m *MockAlertsClientInterface) GetAlerts(arg0 context.Context, arg1 *__.GetAlertsRequest) (*__.GetAlertsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlerts", arg0, arg1)
+	ret0, _ := ret[0].(*__.GetAlertsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlerts indicates an expected call of GetAlerts.
+func (mr *MockAlertsClientInterfaceMockRecorder) GetAlerts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockAlertsClientInterface)(nil).GetAlerts), arg0, arg1)
+}
+
 // UpdateAlert mocks base method.
 func (m *MockAlertsClientInterface) UpdateAlert(arg0 context.Context, arg1 *__.UpdateAlertByUniqueIdRequest) (*__.UpdateAlertByUniqueIdResponse, error) {
 	m.ctrl.T.Helper()
@@ -98,3 +113,18 @@ func (mr *MockAlertsClientInterfaceMockRecorder) UpdateAlert(arg0, arg1 any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlert", reflect.TypeOf((*MockAlertsClientInterface)(nil).UpdateAlert), arg0, arg1)
 }
+
+// ValidateAlert mocks base method.
+func (m *MockAlertsClientInterface) ValidateAlert(arg0 context.Context, arg1 *__.ValidateAlertRequest) (*__.ValidateAlertResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAlert", arg0, arg1)
+	ret0, _ := ret[0].(*__.ValidateAlertResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAlert indicates an expected call of ValidateAlert.
+func (mr *MockAlertsClientInterfaceMockRecorder) ValidateAlert(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAlert", reflect.TypeOf((*MockAlertsClientInterface)(nil).ValidateAlert), arg0, arg1)
+}
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	cxsdk "github.com/coralogix/coralogix-management-sdk/go"
+	clientset "github.com/coralogix/coralogix-operator/controllers/clientset"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -99,6 +100,21 @@ func (mr *MockOutboundWebhooksClientInterfaceMockRecorder) List(ctx, req any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOutboundWebhooksClientInterface)(nil).List), ctx, req)
 }
 
+// TestByID mocks base method.
+func (m *MockOutboundWebhooksClientInterface) TestByID(ctx context.Context, req *cxsdk.TestExistingOutgoingWebhookRequest) (clientset.TestOutgoingWebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestByID", ctx, req)
+	ret0, _ := ret[0].(clientset.TestOutgoingWebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestByID indicates an expected call of TestByID.
+func (mr *MockOutboundWebhooksClientInterfaceMockRecorder) TestByID(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestByID", reflect.TypeOf((*MockOutboundWebhooksClientInterface)(nil).TestByID), ctx, req)
+}
+
 // Update mocks base method.
 func (m *MockOutboundWebhooksClientInterface) Update(ctx context.Context, req *cxsdk.UpdateOutgoingWebhookRequest) (*cxsdk.UpdateOutgoingWebhookResponse, error) {
 	m.ctrl.T.Helper()
@@ -113,3 +129,54 @@ func (mr *MockOutboundWebhooksClientInterfaceMockRecorder) Update(ctx, req any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOutboundWebhooksClientInterface)(nil).Update), ctx, req)
 }
+
+// MockTestOutgoingWebhookResponse is a mock of TestOutgoingWebhookResponse interface.
+type MockTestOutgoingWebhookResponse struct {
+	ctrl     *gomock.Controller
+	recorder *MockTestOutgoingWebhookResponseMockRecorder
+}
+
+// MockTestOutgoingWebhookResponseMockRecorder is the mock recorder for MockTestOutgoingWebhookResponse.
+type MockTestOutgoingWebhookResponseMockRecorder struct {
+	mock *MockTestOutgoingWebhookResponse
+}
+
+// NewMockTestOutgoingWebhookResponse creates a new mock instance.
+func NewMockTestOutgoingWebhookResponse(ctrl *gomock.Controller) *MockTestOutgoingWebhookResponse {
+	mock := &MockTestOutgoingWebhookResponse{ctrl: ctrl}
+	mock.recorder = &MockTestOutgoingWebhookResponseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTestOutgoingWebhookResponse) EXPECT() *MockTestOutgoingWebhookResponseMockRecorder {
+	return m.recorder
+}
+
+// GetFailure mocks base method.
+func (m *MockTestOutgoingWebhookResponse) GetFailure() *cxsdk.TestOutgoingWebhookFailure {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFailure")
+	ret0, _ := ret[0].(*cxsdk.TestOutgoingWebhookFailure)
+	return ret0
+}
+
+// GetFailure indicates an expected call of GetFailure.
+func (mr *MockTestOutgoingWebhookResponseMockRecorder) GetFailure() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailure", reflect.TypeOf((*MockTestOutgoingWebhookResponse)(nil).GetFailure))
+}
+
+// GetSuccess mocks base method.
+func (m *MockTestOutgoingWebhookResponse) GetSuccess() *cxsdk.TestOutgoingWebhookSuccess {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuccess")
+	ret0, _ := ret[0].(*cxsdk.TestOutgoingWebhookSuccess)
+	return ret0
+}
+
+// GetSuccess indicates an expected call of GetSuccess.
+func (mr *MockTestOutgoingWebhookResponseMockRecorder) GetSuccess() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuccess", reflect.TypeOf((*MockTestOutgoingWebhookResponse)(nil).GetSuccess))
+}
@@ -85,6 +85,21 @@ func (mr *MockRecordingRulesGroupsClientInterfaceMockRecorder) Get(ctx, req any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRecordingRulesGroupsClientInterface)(nil).Get), ctx, req)
 }
 
+// List mocks base method.
+func (m *MockRecordingRulesGroupsClientInterface) List(ctx context.Context) (*cxsdk.ListRuleGroupSetResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].(*cxsdk.ListRuleGroupSetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRecordingRulesGroupsClientInterfaceMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRecordingRulesGroupsClientInterface)(nil).List), ctx)
+}
+
 // Update mocks base method.
 func (m *MockRecordingRulesGroupsClientInterface) Update(ctx context.Context, req *cxsdk.UpdateRuleGroupSetRequest) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
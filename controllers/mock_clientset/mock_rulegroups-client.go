@@ -84,6 +84,21 @@ func (mr *MockRuleGroupsClientInterfaceMockRecorder) Get(ctx, req any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRuleGroupsClientInterface)(nil).Get), ctx, req)
 }
 
+// List mocks base method.
+func (m *MockRuleGroupsClientInterface) List(ctx context.Context) ([]*cxsdk.RuleGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*cxsdk.RuleGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRuleGroupsClientInterfaceMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRuleGroupsClientInterface)(nil).List), ctx)
+}
+
 // Update mocks base method.
 func (m *MockRuleGroupsClientInterface) Update(ctx context.Context, req *cxsdk.UpdateRuleGroupRequest) (*cxsdk.UpdateRuleGroupResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,46 @@
+package overrides
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Parse parses a comma separated list of a default value and <key>=<value> overrides, e.g. "10m,Alert=5m", where
+// each key is one of keys. Each value is parsed with parseValue, and the errors refer to the values as what, e.g.
+// "resync period", and to the keys as keyName, e.g. "kind". A missing default is the zero value of T.
+func Parse[T any](value, what, keyName string, keys []string, parseValue func(string) (T, error)) (T, map[string]T, error) {
+	var defaultValue T
+	byKey := make(map[string]T)
+	if strings.TrimSpace(value) == "" {
+		return defaultValue, byKey, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		key, entryValue, isOverride := strings.Cut(entry, "=")
+		if !isOverride {
+			entryValue, key = key, ""
+		}
+
+		parsed, err := parseValue(strings.TrimSpace(entryValue))
+		if err != nil {
+			var zero T
+			return zero, nil, fmt.Errorf("invalid %s %q: %w", what, entry, err)
+		}
+
+		if !isOverride {
+			defaultValue = parsed
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		if !slices.Contains(keys, key) {
+			var zero T
+			return zero, nil, fmt.Errorf("invalid %s %q: %s should be one of %q", what, entry, keyName, keys)
+		}
+		byKey[key] = parsed
+	}
+
+	return defaultValue, byKey, nil
+}
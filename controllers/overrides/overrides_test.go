@@ -0,0 +1,65 @@
+package overrides
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	keys := []string{"Alert", "RuleGroup"}
+	tests := []struct {
+		name        string
+		value       string
+		wantDefault int
+		wantByKey   map[string]int
+		wantError   string
+	}{
+		{
+			name:      "empty value",
+			value:     " ",
+			wantByKey: map[string]int{},
+		},
+		{
+			name:        "default only",
+			value:       "2",
+			wantDefault: 2,
+			wantByKey:   map[string]int{},
+		},
+		{
+			name:        "default and overrides",
+			value:       "2, Alert = 4,RuleGroup=1",
+			wantDefault: 2,
+			wantByKey:   map[string]int{"Alert": 4, "RuleGroup": 1},
+		},
+		{
+			name:      "overrides only",
+			value:     "Alert=4",
+			wantByKey: map[string]int{"Alert": 4},
+		},
+		{
+			name:      "unknown key",
+			value:     "Dashboard=2",
+			wantError: `invalid number "Dashboard=2": kind should be one of ["Alert" "RuleGroup"]`,
+		},
+		{
+			name:      "invalid value",
+			value:     "Alert=four",
+			wantError: `invalid number "Alert=four": strconv.Atoi: parsing "four": invalid syntax`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaultValue, byKey, err := Parse(tt.value, "number", "kind", keys, strconv.Atoi)
+			if tt.wantError != "" {
+				assert.EqualError(t, err, tt.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDefault, defaultValue)
+			assert.Equal(t, tt.wantByKey, byKey)
+		})
+	}
+}
@@ -8,28 +8,29 @@ import (
 	"time"
 
 	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	"github.com/coralogix/coralogix-operator/controllers/alphacontrollers"
 	"github.com/coralogix/coralogix-operator/controllers/clientset"
 	"github.com/go-logr/logr"
 	"go.uber.org/zap/zapcore"
 
 	prometheus "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-const (
-	defaultCoralogixNotificationPeriod int32 = 5
-)
-
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch
 
 //+kubebuilder:rbac:groups=coralogix.com,resources=recordingrulegroupsets,verbs=get;list;watch;create;update;patch;delete
@@ -45,6 +46,11 @@ type PrometheusRuleReconciler struct {
 	client.Client
 	CoralogixClientSet clientset.ClientSetInterface
 	Scheme             *runtime.Scheme
+	Recorder           record.EventRecorder
+	// MaxConcurrentReconciles is the number of resources reconciled in parallel. Zero means one.
+	MaxConcurrentReconciles int
+	// WatchFilter restricts the reconciled PrometheusRules to some namespaces and labels. The zero value reconciles all of them.
+	WatchFilter alphacontrollers.WatchFilter
 }
 
 func (r *PrometheusRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -66,6 +72,7 @@ func (r *PrometheusRuleReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		err := r.convertPrometheusRuleRecordingRuleToCxRecordingRule(ctx, log, prometheusRule, req)
 		if err != nil {
 			log.Error(err, "Received an error while trying to convert PrometheusRule to RecordingRule CRD")
+			r.Recorder.Event(prometheusRule, corev1.EventTypeWarning, "ConversionFailed", err.Error())
 			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 		}
 	}
@@ -74,6 +81,7 @@ func (r *PrometheusRuleReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		err := r.convertPrometheusRuleAlertToCxAlert(ctx, prometheusRule)
 		if err != nil {
 			log.Error(err, "Received an error while trying to convert PrometheusRule to Alert CRD")
+			r.Recorder.Event(prometheusRule, corev1.EventTypeWarning, "ConversionFailed", err.Error())
 			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
 		}
 	}
@@ -92,6 +100,8 @@ func (r *PrometheusRuleReconciler) convertPrometheusRuleRecordingRuleToCxRecordi
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: prometheusRule.Namespace,
 			Name:      prometheusRule.Name,
+			// The RecordingRuleGroupSet keeps the labels selecting the PrometheusRule, for the operator to watch it too.
+			Labels: r.WatchFilter.SelectedLabels(prometheusRule.Labels),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion: prometheusRule.APIVersion,
@@ -109,16 +119,22 @@ func (r *PrometheusRuleReconciler) convertPrometheusRuleRecordingRuleToCxRecordi
 			if err = r.Create(ctx, recordingRuleGroupSet); err != nil {
 				return fmt.Errorf("received an error while trying to create RecordingRuleGroupSet CRD: %w", err)
 			}
+			r.Recorder.Eventf(prometheusRule, corev1.EventTypeNormal, "RecordingRuleGroupSetCreated", "Created RecordingRuleGroupSet %s", recordingRuleGroupSet.Name)
 			return nil
 		}
 
 		return fmt.Errorf("received an error while trying to get RecordingRuleGroupSet CRD: %w", err)
 	}
 
+	resourceVersion := recordingRuleGroupSet.ResourceVersion
 	recordingRuleGroupSet.Spec = recordingRuleGroupSetSpec
 	if err := r.Client.Update(ctx, recordingRuleGroupSet); err != nil {
 		return fmt.Errorf("received an error while trying to update RecordingRuleGroupSet CRD: %w", err)
 	}
+	// Updates that change nothing keep the resource version, and are not worth an event.
+	if recordingRuleGroupSet.ResourceVersion != resourceVersion {
+		r.Recorder.Eventf(prometheusRule, corev1.EventTypeNormal, "RecordingRuleGroupSetUpdated", "Updated RecordingRuleGroupSet %s", recordingRuleGroupSet.Name)
+	}
 
 	return nil
 }
@@ -183,13 +199,16 @@ func (r *PrometheusRuleReconciler) convertPrometheusRuleAlertToCxAlert(ctx conte
 							UID:        prometheusRule.UID,
 						},
 					}
-					alertCRD.Labels = map[string]string{"app.kubernetes.io/managed-by": prometheusRule.Name}
+					// The Alert keeps the labels selecting the PrometheusRule, for the operator to watch it too.
+					alertCRD.Labels = r.WatchFilter.SelectedLabels(prometheusRule.Labels)
+					alertCRD.Labels["app.kubernetes.io/managed-by"] = prometheusRule.Name
 					if val, ok := prometheusRule.Labels["app.coralogix.com/managed-by-alertmanger-config"]; ok {
 						alertCRD.Labels["app.coralogix.com/managed-by-alertmanger-config"] = val
 					}
 					if err = r.Create(ctx, alertCRD); err != nil {
 						return fmt.Errorf("received an error while trying to create Alert CRD: %w", err)
 					}
+					r.Recorder.Eventf(prometheusRule, corev1.EventTypeNormal, "AlertCreated", "Created Alert %s", alertCRD.Name)
 					continue
 				} else {
 					return fmt.Errorf("received an error while trying to get Alert CRD: %w", err)
@@ -197,6 +216,7 @@ func (r *PrometheusRuleReconciler) convertPrometheusRuleAlertToCxAlert(ctx conte
 			}
 
 			//Converting the PrometheusRule to the desired Alert.
+			resourceVersion := alertCRD.ResourceVersion
 			alertCRD.Spec = prometheusRuleToCoralogixAlertSpec(rule)
 			alertCRD.OwnerReferences = []metav1.OwnerReference{
 				{
@@ -212,6 +232,9 @@ func (r *PrometheusRuleReconciler) convertPrometheusRuleAlertToCxAlert(ctx conte
 			if err := r.Update(ctx, alertCRD); err != nil {
 				return fmt.Errorf("received an error while trying to update Alert CRD: %w", err)
 			}
+			if alertCRD.ResourceVersion != resourceVersion {
+				r.Recorder.Eventf(prometheusRule, corev1.EventTypeNormal, "AlertUpdated", "Updated Alert %s", alertCRD.Name)
+			}
 		}
 	}
 
@@ -225,6 +248,7 @@ func (r *PrometheusRuleReconciler) convertPrometheusRuleAlertToCxAlert(ctx conte
 			if err := r.Delete(ctx, &alert); err != nil {
 				return fmt.Errorf("received an error while trying to delete Alert CRD: %w", err)
 			}
+			r.Recorder.Eventf(prometheusRule, corev1.EventTypeNormal, "AlertDeleted", "Deleted Alert %s", alert.Name)
 		}
 	}
 	return nil
@@ -360,7 +384,7 @@ func getNotificationPeriod(rule prometheus.Rule) int32 {
 		}
 	}
 
-	return defaultCoralogixNotificationPeriod
+	return coralogixv1alpha1.DefaultRetriggeringPeriodMinutes
 }
 
 var prometheusAlertForToCoralogixPromqlAlertTimeWindow = map[prometheus.Duration]coralogixv1alpha1.MetricTimeWindow{
@@ -391,7 +415,8 @@ func (r *PrometheusRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&prometheus.PrometheusRule{}).
+		For(&prometheus.PrometheusRule{}, builder.WithPredicates(r.WatchFilter.Predicate())).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		WithEventFilter(predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool {
 				return shouldTrackPrometheusRules(e.Object.GetLabels())
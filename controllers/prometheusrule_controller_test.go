@@ -45,6 +45,7 @@ func setupReconciler(t *testing.T, ctx context.Context, clientSet *mock_clientse
 	r := PrometheusRuleReconciler{
 		Client:             withWatch,
 		Scheme:             mgr.GetScheme(),
+		Recorder:           mgr.GetEventRecorderFor("coralogix-operator"),
 		CoralogixClientSet: clientSet,
 	}
 	r.SetupWithManager(mgr)
@@ -0,0 +1,104 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/coralogix/coralogix-operator/controllers/alphacontrollers"
+	"github.com/coralogix/coralogix-operator/controllers/clientset"
+)
+
+// runExport runs the export subcommand, writing the manifests of the existing Coralogix resources, and
+// returns its exit code.
+func runExport(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [flags]\n\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Writes the manifests of the resources managing the existing objects of a Coralogix team. "+
+			"Applying them imports the objects instead of creating copies.")
+		flags.PrintDefaults()
+	}
+
+	region := os.Getenv("CORALOGIX_REGION")
+	flags.StringVar(&region, "region", region, fmt.Sprintf("The region of your Coralogix cluster. Can be one of %q. Conflicts with 'domain'.", clientset.ValidRegions))
+
+	domain := os.Getenv("CORALOGIX_DOMAIN")
+	flags.StringVar(&domain, "domain", domain, "The domain of your Coralogix cluster. Conflicts with 'region'.")
+
+	apiKey := os.Getenv("CORALOGIX_API_KEY")
+	flags.StringVar(&apiKey, "api-key", apiKey, "The proper api-key based on your Coralogix cluster's region.")
+
+	var kinds string
+	flags.StringVar(&kinds, "kinds", "", fmt.Sprintf("Comma separated kinds to export, of %q. Defaults to all of them.", alphacontrollers.ExportKinds))
+
+	var namespace string
+	flags.StringVar(&namespace, "namespace", "", "The namespace of the exported resources. Defaults to the namespace they are applied to.")
+
+	var output string
+	flags.StringVar(&output, "output", "", "The file the manifests are written to. Defaults to stdout.")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	exportKinds, err := alphacontrollers.ParseExportKinds(kinds)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid arguments for export: %v\n", err)
+		return 2
+	}
+
+	targetUrl, err := clientset.TargetUrl(region, domain)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid arguments for export: %v\n", err)
+		return 2
+	}
+
+	if apiKey == "" {
+		fmt.Fprintln(os.Stderr, "invalid arguments for export: api-key can not be empty")
+		return 2
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error on creating %s: %v\n", output, err)
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+
+	coralogixClientSet := clientset.NewClientSet(targetUrl, apiKey)
+	defer coralogixClientSet.Close()
+
+	exporter := &alphacontrollers.Exporter{ClientSet: coralogixClientSet, Namespace: namespace, Warnings: os.Stderr}
+	if err = exporter.Export(context.Background(), exportKinds, w); err != nil {
+		fmt.Fprintf(os.Stderr, "error on exporting: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -17,51 +17,40 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	prometheus "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	prometheusv1alpha "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
-	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
-	utils "github.com/coralogix/coralogix-operator/apis"
+	"github.com/coralogix/coralogix-operator/controllers/accounts"
 	"github.com/coralogix/coralogix-operator/controllers/clientset"
+	"github.com/coralogix/coralogix-operator/controllers/metrics"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	coralogixv1alpha1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1alpha1"
+	coralogixv1beta1 "github.com/coralogix/coralogix-operator/apis/coralogix/v1beta1"
 	"github.com/coralogix/coralogix-operator/controllers"
 	"github.com/coralogix/coralogix-operator/controllers/alphacontrollers"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	//+kubebuilder:scaffold:imports
 )
 
 var (
-	scheme          = runtime.NewScheme()
-	setupLog        = ctrl.Log.WithName("setup")
-	regionToGrpcUrl = map[string]string{
-		"APAC1":   "ng-api-grpc.app.coralogix.in:443",
-		"AP1":     "ng-api-grpc.app.coralogix.in:443",
-		"APAC2":   "ng-api-grpc.coralogixsg.com:443",
-		"AP2":     "ng-api-grpc.coralogixsg.com:443",
-		"EUROPE1": "ng-api-grpc.coralogix.com:443",
-		"EU1":     "ng-api-grpc.coralogix.com:443",
-		"EUROPE2": "ng-api-grpc.eu2.coralogix.com:443",
-		"EU2":     "ng-api-grpc.eu2.coralogix.com:443",
-		"USA1":    "ng-api-grpc.coralogix.us:443",
-		"US1":     "ng-api-grpc.coralogix.us:443",
-		"USA2":    "ng-api-grpc.cx498.coralogix.com:443",
-		"US2":     "ng-api-grpc.cx498.coralogix.com:443",
-	}
-	validRegions = utils.GetKeys(regionToGrpcUrl)
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
 )
 
 func init() {
@@ -71,11 +60,17 @@ func init() {
 
 	utilruntime.Must(coralogixv1alpha1.AddToScheme(scheme))
 
+	utilruntime.Must(coralogixv1beta1.AddToScheme(scheme))
+
 	utilruntime.Must(prometheusv1alpha.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(runExport(os.Args[2:]))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
@@ -85,9 +80,12 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	var leaderElectionID string
+	flag.StringVar(&leaderElectionID, "leader-election-id", "9e1892e3.coralogix", "The name of the lease used for leader election. "+
+		"Operators watching different namespaces or labels in the same namespace need different ids.")
 
 	region := os.Getenv("CORALOGIX_REGION")
-	flag.StringVar(&region, "region", region, fmt.Sprintf("The region of your Coralogix cluster. Can be one of %q. Conflicts with 'domain'.", validRegions))
+	flag.StringVar(&region, "region", region, fmt.Sprintf("The region of your Coralogix cluster. Can be one of %q. Conflicts with 'domain'.", clientset.ValidRegions))
 
 	domain := os.Getenv("CORALOGIX_DOMAIN")
 	flag.StringVar(&domain, "domain", domain, "The domain of your Coralogix cluster. Conflicts with 'region'.")
@@ -95,44 +93,118 @@ func main() {
 	apiKey := os.Getenv("CORALOGIX_API_KEY")
 	flag.StringVar(&apiKey, "api-key", apiKey, "The proper api-key based on your Coralogix cluster's region.")
 
+	var apiKeySecret string
+	flag.StringVar(&apiKeySecret, "api-key-secret", "", "A Secret key holding the api-key, in the form <namespace>/<name>:<key>. "+
+		"The Secret is watched and a rotated api-key is used without restarting the operator. Conflicts with 'api-key'.")
+
+	var connectivityCheckInterval time.Duration
+	flag.DurationVar(&connectivityCheckInterval, "connectivity-check-interval", time.Minute, "How often the api-key and the connectivity to the Coralogix API "+
+		"are checked, as reported by the coralogix_operator_api_connectivity metric. Zero disables the checks, the api-key is still validated at startup.")
+
+	var webhooksCacheTTL time.Duration
+	flag.DurationVar(&webhooksCacheTTL, "webhooks-cache-ttl", time.Minute, "How long the outbound webhooks listed to resolve the integrationName of alerts "+
+		"are cached. A webhook missing from the cache is listed again regardless.")
+
 	var prometheusRuleController bool
 	flag.BoolVar(&prometheusRuleController, "prometheus-rule-controller", true, "Determine if the prometheus rule controller should be started. Default is true.")
 
 	var recordingRuleGroupSetSuffix string
 	flag.StringVar(&recordingRuleGroupSetSuffix, "recording-rule-group-set-suffix", "", "Suffix to be added to the RecordingRuleGroupSet")
 
+	var resyncPeriod string
+	flag.StringVar(&resyncPeriod, "resync-period", "", "How often remote resources are compared with their spec, to detect changes made outside of the operator. "+
+		fmt.Sprintf("Either a duration for all kinds, <Kind>=<duration> overrides for one of %q, or both, e.g. '10m,Alert=5m'. ", alphacontrollers.ResyncKinds)+
+		"Disabled by default.")
+
+	var apiRateLimit string
+	flag.StringVar(&apiRateLimit, "api-rate-limit", "", "Client-side limit of the calls to the Coralogix API, shared by all accounts. "+
+		fmt.Sprintf("Either <qps>[:<burst>] for all calls, <service>=<qps>[:<burst>] overrides for one of %q, or both, e.g. '20:40,alerts=5'. ", clientset.RateLimitServices)+
+		"Unlimited by default. Calls throttled by Coralogix are retried after the delay it asks for regardless.")
+
+	var maxConcurrentReconciles string
+	flag.StringVar(&maxConcurrentReconciles, "max-concurrent-reconciles", "", "How many resources are reconciled in parallel. "+
+		fmt.Sprintf("Either a number for all kinds, <Kind>=<number> overrides for one of %q, or both, e.g. '2,Alert=4'. ", alphacontrollers.ConcurrencyKinds)+
+		"Defaults to 1.")
+
+	var deletionPolicy string
+	flag.StringVar(&deletionPolicy, "deletion-policy", string(coralogixv1alpha1.DeletionPolicyDelete), "What happens to the remote object of a deleted resource "+
+		fmt.Sprintf("without a deletionPolicy in its spec. Can be one of %q. Retain leaves it in Coralogix, no longer managed by the operator.", coralogixv1alpha1.DeletionPolicies))
+
+	var dryRun bool
+	flag.BoolVar(&dryRun, "dry-run", false, "Record the changes that would be made to the remote objects in the status.plan and events of their resources, "+
+		"instead of making them. Deleted resources leave their remote objects as is.")
+
+	var watchNamespaces string
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "", "A comma separated list of the namespaces whose resources are reconciled, e.g. 'team-a,team-b'. "+
+		"All namespaces by default.")
+
+	var resourceLabelSelector string
+	flag.StringVar(&resourceLabelSelector, "resource-label-selector", "", "A label selector of the reconciled resources, e.g. 'team=a'. "+
+		"Resources generated from PrometheusRules and AlertmanagerConfigs keep the labels it refers to. All resources by default.")
+
+	var enableWebhooks bool
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Serve the admission webhooks validating the custom resources, and the webhook converting them between versions. "+
+		"Requires the webhook configurations and a serving certificate in the cluster.")
+
+	var validateAlertsRemotely bool
+	flag.BoolVar(&validateAlertsRemotely, "validate-alerts-remotely", false, "Also validate alerts with the Coralogix API when they are applied. "+
+		"Requires 'enable-webhooks'.")
+
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	if region != "" && domain != "" {
-		err := fmt.Errorf("region and domain flags are mutually exclusive")
+	targetUrl, err := clientset.TargetUrl(region, domain)
+	if err != nil {
+		setupLog.Error(err, "invalid arguments for running operator")
+		os.Exit(1)
+	}
+
+	resyncPeriods, err := alphacontrollers.ParseResyncPeriods(resyncPeriod)
+	if err != nil {
+		setupLog.Error(err, "invalid arguments for running operator")
+		os.Exit(1)
+	}
+
+	rateLimits, err := clientset.ParseRateLimits(apiRateLimit)
+	if err != nil {
+		setupLog.Error(err, "invalid arguments for running operator")
+		os.Exit(1)
+	}
+
+	concurrency, err := alphacontrollers.ParseMaxConcurrentReconciles(maxConcurrentReconciles)
+	if err != nil {
+		setupLog.Error(err, "invalid arguments for running operator")
+		os.Exit(1)
+	}
+
+	defaultDeletionPolicy, err := coralogixv1alpha1.ParseDeletionPolicy(deletionPolicy)
+	if err != nil {
 		setupLog.Error(err, "invalid arguments for running operator")
 		os.Exit(1)
 	}
 
-	if region == "" && domain == "" {
-		err := fmt.Errorf("region or domain must be set")
+	watchFilter, err := alphacontrollers.ParseWatchFilter(watchNamespaces, resourceLabelSelector)
+	if err != nil {
 		setupLog.Error(err, "invalid arguments for running operator")
 		os.Exit(1)
 	}
 
-	var targetUrl string
-	if region != "" {
-		if !slices.Contains(validRegions, region) {
-			err := fmt.Errorf("region value is '%s', but can be one of %q", region, validRegions)
+	var apiKeySecretRef controllers.SecretKeyReference
+	if apiKeySecret != "" {
+		if apiKey != "" {
+			err := fmt.Errorf("api-key and api-key-secret flags are mutually exclusive")
 			setupLog.Error(err, "invalid arguments for running operator")
 			os.Exit(1)
 		}
-		targetUrl = regionToGrpcUrl[region]
-	} else if domain != "" {
-		targetUrl = fmt.Sprintf("ng-api-grpc.%s:443", domain)
-	}
-
-	if apiKey == "" {
-		err := fmt.Errorf("api-key can not be empty")
+		if apiKeySecretRef, err = controllers.ParseSecretKeyReference(apiKeySecret); err != nil {
+			setupLog.Error(err, "invalid arguments for running operator")
+			os.Exit(1)
+		}
+	} else if apiKey == "" {
+		err := fmt.Errorf("api-key or api-key-secret must be set")
 		setupLog.Error(err, "invalid arguments for running operator")
 		os.Exit(1)
 	}
@@ -143,8 +215,16 @@ func main() {
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "9e1892e3.coralogix",
+		LeaderElectionID:       leaderElectionID,
 		PprofBindAddress:       "0.0.0.0:8888",
+		Cache: watchFilter.CacheOptions([]client.Object{
+			&coralogixv1alpha1.Alert{},
+			&coralogixv1alpha1.RuleGroup{},
+			&coralogixv1alpha1.RecordingRuleGroupSet{},
+			&coralogixv1alpha1.OutboundWebhook{},
+			&prometheus.PrometheusRule{},
+			&prometheusv1alpha.AlertmanagerConfig{},
+		}),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -162,61 +242,186 @@ func main() {
 		os.Exit(1)
 	}
 
+	if apiKeySecret != "" {
+		// The cache isn't started yet, so the initial api-key is read directly from the API server.
+		if apiKey, err = controllers.GetAPIKeyFromSecret(context.Background(), mgr.GetAPIReader(), apiKeySecretRef); err != nil {
+			setupLog.Error(err, "unable to read api-key from secret", "secret", apiKeySecretRef.String())
+			os.Exit(1)
+		}
+	}
+
+	clientSetOptions := []clientset.Option{clientset.WithRateLimiter(clientset.NewRateLimiter(rateLimits))}
+	coralogixClientSet := clientset.NewClientSet(targetUrl, apiKey, clientSetOptions...)
+	if err = mgr.Add(coralogixClientSet); err != nil {
+		setupLog.Error(err, "unable to set up Coralogix API connection shutdown")
+		os.Exit(1)
+	}
+
+	// The api-key is validated at startup, so a wrong key or region fails fast instead of failing every reconciliation.
+	// The webhooks of the custom resources don't need the Coralogix API though, so they are served regardless.
+	connectivityChecker := clientset.NewConnectivityChecker(coralogixClientSet.OutboundWebhooks(), targetUrl, connectivityCheckInterval,
+		metrics.ReportAPIConnectivity)
+	if err = connectivityChecker.Check(context.Background()); err != nil {
+		setupLog.Error(err, "unable to connect to the Coralogix API")
+		if !enableWebhooks {
+			os.Exit(1)
+		}
+	}
+	if connectivityCheckInterval > 0 {
+		if err = mgr.Add(connectivityChecker); err != nil {
+			setupLog.Error(err, "unable to set up Coralogix API connectivity checks")
+			os.Exit(1)
+		}
+	}
+
+	instrumentedClientSet := metrics.InstrumentClientSet(coralogixClientSet)
+	accountClientSets := accounts.NewClientSets(mgr.GetClient(), mgr.GetAPIReader(), instrumentedClientSet)
+	accountClientSets.Options = clientSetOptions
+	// The outbound webhooks of an account are cached until its clientset is closed.
+	webhookResolvers := clientset.NewWebhookResolvers(webhooksCacheTTL)
+	accountClientSets.WebhookResolvers = webhookResolvers
+	if err = mgr.Add(accountClientSets); err != nil {
+		setupLog.Error(err, "unable to set up Coralogix accounts connections shutdown")
+		os.Exit(1)
+	}
+
+	recorder := mgr.GetEventRecorderFor("coralogix-operator")
+	if apiKeySecret != "" {
+		if err = (&controllers.APIKeySecretReconciler{
+			CoralogixClientSet: coralogixClientSet,
+			Recorder:           recorder,
+			SecretRef:          apiKeySecretRef,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "APIKeySecret")
+			os.Exit(1)
+		}
+	}
 	if err = (&alphacontrollers.RuleGroupReconciler{
-		CoralogixClientSet: clientset.NewClientSet(targetUrl, apiKey),
-		Client:             mgr.GetClient(),
-		Scheme:             mgr.GetScheme(),
+		CoralogixClientSet:      instrumentedClientSet,
+		Accounts:                accountClientSets,
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		Recorder:                recorder,
+		ResyncPeriod:            resyncPeriods.For("RuleGroup"),
+		MaxConcurrentReconciles: concurrency.For("RuleGroup"),
+		DeletionPolicy:          defaultDeletionPolicy,
+		DryRun:                  dryRun,
+		WatchFilter:             watchFilter,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RuleGroup")
 		os.Exit(1)
 	}
 	if err = (&alphacontrollers.AlertReconciler{
-		CoralogixClientSet: clientset.NewClientSet(targetUrl, apiKey),
-		Client:             mgr.GetClient(),
-		Scheme:             mgr.GetScheme(),
+		CoralogixClientSet:      instrumentedClientSet,
+		Accounts:                accountClientSets,
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		Recorder:                recorder,
+		ResyncPeriod:            resyncPeriods.For("Alert"),
+		MaxConcurrentReconciles: concurrency.For("Alert"),
+		DeletionPolicy:          defaultDeletionPolicy,
+		DryRun:                  dryRun,
+		WatchFilter:             watchFilter,
+		WebhookResolvers:        webhookResolvers,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Alert")
 		os.Exit(1)
 	}
 	if prometheusRuleController {
 		if err = (&controllers.PrometheusRuleReconciler{
-			CoralogixClientSet: clientset.NewClientSet(targetUrl, apiKey),
-			Client:             mgr.GetClient(),
-			Scheme:             mgr.GetScheme(),
+			CoralogixClientSet:      instrumentedClientSet,
+			Client:                  mgr.GetClient(),
+			Scheme:                  mgr.GetScheme(),
+			Recorder:                recorder,
+			MaxConcurrentReconciles: concurrency.For("PrometheusRule"),
+			WatchFilter:             watchFilter,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "RecordingRuleGroup")
 			os.Exit(1)
 		}
 	}
 	if err = (&alphacontrollers.RecordingRuleGroupSetReconciler{
-		CoralogixClientSet:          clientset.NewClientSet(targetUrl, apiKey),
+		CoralogixClientSet:          instrumentedClientSet,
+		Accounts:                    accountClientSets,
 		Client:                      mgr.GetClient(),
 		Scheme:                      mgr.GetScheme(),
+		Recorder:                    recorder,
 		RecordingRuleGroupSetSuffix: recordingRuleGroupSetSuffix,
+		ResyncPeriod:                resyncPeriods.For("RecordingRuleGroupSet"),
+		MaxConcurrentReconciles:     concurrency.For("RecordingRuleGroupSet"),
+		DeletionPolicy:              defaultDeletionPolicy,
+		DryRun:                      dryRun,
+		WatchFilter:                 watchFilter,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RecordingRuleGroupSet")
 		os.Exit(1)
 	}
 	if err = (&alphacontrollers.OutboundWebhookReconciler{
-		OutboundWebhooksClient: clientset.NewClientSet(targetUrl, apiKey).OutboundWebhooks(),
-		Client:                 mgr.GetClient(),
-		Scheme:                 mgr.GetScheme(),
+		OutboundWebhooksClient:  instrumentedClientSet.OutboundWebhooks(),
+		Accounts:                accountClientSets,
+		Client:                  mgr.GetClient(),
+		Secrets:                 mgr.GetAPIReader(),
+		Scheme:                  mgr.GetScheme(),
+		Recorder:                recorder,
+		ResyncPeriod:            resyncPeriods.For("OutboundWebhook"),
+		MaxConcurrentReconciles: concurrency.For("OutboundWebhook"),
+		DeletionPolicy:          defaultDeletionPolicy,
+		DryRun:                  dryRun,
+		WatchFilter:             watchFilter,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OutboundWebhook")
 		os.Exit(1)
 	}
 	if prometheusRuleController {
 		if err = (&controllers.AlertmanagerConfigReconciler{
-			CoralogixClientSet: clientset.NewClientSet(targetUrl, apiKey),
-			Client:             mgr.GetClient(),
-			Scheme:             mgr.GetScheme(),
+			CoralogixClientSet:      instrumentedClientSet,
+			Client:                  mgr.GetClient(),
+			Scheme:                  mgr.GetScheme(),
+			Recorder:                recorder,
+			MaxConcurrentReconciles: concurrency.For("AlertmanagerConfig"),
+			WatchFilter:             watchFilter,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "RecordingRuleGroup")
 			os.Exit(1)
 		}
 	}
+	if enableWebhooks {
+		if err = (&coralogixv1alpha1.AlertDefaulter{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Alert")
+			os.Exit(1)
+		}
+		if err = (&coralogixv1alpha1.AlertValidator{
+			ClientSets:       accountClientSets,
+			Client:           mgr.GetClient(),
+			ValidateRemotely: validateAlertsRemotely,
+		}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Alert")
+			os.Exit(1)
+		}
+		if err = (&coralogixv1alpha1.RuleGroupValidator{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RuleGroup")
+			os.Exit(1)
+		}
+		if err = (&coralogixv1alpha1.RecordingRuleGroupSetValidator{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RecordingRuleGroupSet")
+			os.Exit(1)
+		}
+		if err = (&coralogixv1alpha1.OutboundWebhookValidator{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OutboundWebhook")
+			os.Exit(1)
+		}
+		if err = coralogixv1beta1.SetupConversionWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create conversion webhook")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	if err = metrics.RegisterResourcesCollector(mgr.GetClient()); err != nil {
+		setupLog.Error(err, "unable to register resources metrics")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -4,6 +4,35 @@
 crds_path="charts/coralogix-operator/templates/crds"
 bases_path="config/crd/bases"
 
+# Let the CRD convert its versions with the webhook of the operator, when the chart enables the webhooks.
+# v1beta1 is only stored when the webhook can convert it, v1alpha1 is stored otherwise.
+add_conversion_webhook() {
+    sed -i \
+        -e 's/^    storage: true$/    storage: {{ not .Values.webhooks.enabled }}/' \
+        -e 's/^    storage: false$/    storage: {{ .Values.webhooks.enabled }}/' \
+        -e '/controller-gen.kubebuilder.io\/version:/a\
+    {{- if and .Values.webhooks.enabled .Values.webhooks.certManager.enabled }}\
+    cert-manager.io/inject-ca-from: {{ .Release.Namespace }}/{{ include "coralogixOperator.fullname" . }}-webhook\
+    {{- end }}' \
+        -e '/^spec:$/a\
+  {{- if .Values.webhooks.enabled }}\
+  conversion:\
+    strategy: Webhook\
+    webhook:\
+      clientConfig:\
+        service:\
+          name: {{ include "coralogixOperator.fullname" . }}-webhook\
+          namespace: {{ .Release.Namespace }}\
+          path: /convert\
+        {{- if not .Values.webhooks.certManager.enabled }}\
+        caBundle: {{ .Values.webhooks.caBundle }}\
+        {{- end }}\
+      conversionReviewVersions:\
+      - v1\
+  {{- end }}' \
+        "$1"
+}
+
 # Get a list of YAML files in the two paths
 crds_files=$(find "$crds_path" -type f -name "*.yaml")
 
@@ -13,6 +42,7 @@ for crd_file in $crds_files; do
     
     if [ -f "$base_file" ]; then
         cp "$base_file" "$crd_file"
+        add_conversion_webhook "$crd_file"
         echo "Replaced CRD file: $crd_file"
     else
         echo "Base file not found for $crd_file"